@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/certusone/solana_exporter/pkg/rpc"
+	"k8s.io/klog/v2"
+)
+
+// Number of slots to walk back from the confirmed slot while looking for one with a block, as its leader may have
+// skipped it.
+const maxBlockTimeLookback = 8
+
+// Windows over which the slot duration is averaged from block times. They are exported once samples cover them.
+var blockTimeWindows = []struct {
+	label    string
+	duration time.Duration
+}{
+	{"1m", time.Minute},
+	{"10m", 10 * time.Minute},
+	{"1h", time.Hour},
+}
+
+type (
+	// blockTimeSampler keeps the block times of confirmed slots sampled over the longest of blockTimeWindows. It is
+	// only used by the block_time poller.
+	blockTimeSampler struct {
+		samples []blockTimeSample
+	}
+
+	blockTimeSample struct {
+		slot      int64
+		blockTime int64
+		at        time.Time
+	}
+)
+
+// add records a sample and forgets those no longer needed for any window.
+func (s *blockTimeSampler) add(sample blockTimeSample) {
+	s.samples = append(s.samples, sample)
+
+	// Keep the newest sample older than the longest window, as the base for it.
+	cutoff := sample.at.Add(-blockTimeWindows[len(blockTimeWindows)-1].duration)
+	i := 0
+	for i+1 < len(s.samples) && !s.samples[i+1].at.After(cutoff) {
+		i++
+	}
+	s.samples = s.samples[i:]
+}
+
+// slotDuration returns the average slot duration over the window before the latest sample, from the block times at
+// both ends. It returns false if the samples do not cover the window yet.
+func (s *blockTimeSampler) slotDuration(window time.Duration) (time.Duration, bool) {
+	if len(s.samples) < 2 {
+		return 0, false
+	}
+	latest := s.samples[len(s.samples)-1]
+	cutoff := latest.at.Add(-window)
+
+	var base *blockTimeSample
+	for i := range s.samples {
+		if s.samples[i].at.After(cutoff) {
+			break
+		}
+		base = &s.samples[i]
+	}
+	if base == nil || latest.slot <= base.slot || latest.blockTime <= base.blockTime {
+		return 0, false
+	}
+	return time.Duration(latest.blockTime-base.blockTime) * time.Second / time.Duration(latest.slot-base.slot), true
+}
+
+// blockTime returns the block time of the latest block at or before slot, and that block's slot.
+func (c *solanaCollector) blockTime(ctx context.Context, slot int64) (int64, int64, error) {
+	var lastErr error
+	for s := slot; s > slot-maxBlockTimeLookback && s >= 0; s-- {
+		blockTime, err := c.rpcClient.GetBlockTime(ctx, s)
+		var rpcErr *rpc.Error
+		if errors.As(err, &rpcErr) {
+			// Skipped slot or block not available (yet).
+			lastErr = err
+			continue
+		} else if err != nil {
+			return 0, 0, err
+		}
+		if blockTime == 0 {
+			// Older nodes return null for slots without a block time.
+			lastErr = fmt.Errorf("no block time for slot %d", s)
+			continue
+		}
+		return blockTime, s, nil
+	}
+	return 0, 0, fmt.Errorf("no block time in the %d slots up to %d: %w", maxBlockTimeLookback, slot, lastErr)
+}
+
+func (c *solanaCollector) pollBlockTime(ctx context.Context) error {
+	info, err := c.rpcClient.GetEpochInfo(ctx, rpc.CommitmentMax)
+	if err != nil {
+		return err
+	}
+
+	blockTime, slot, err := c.blockTime(ctx, info.AbsoluteSlot)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	klog.V(2).Infof("block time of slot %d: %v", slot, blockTime)
+
+	c.blockTimes.add(blockTimeSample{slot: slot, blockTime: blockTime, at: now})
+
+	blockTimeDrift.Set(now.Sub(time.Unix(blockTime, 0)).Seconds())
+
+	// Project the end of the epoch with the longest window available, falling back to the slot duration measured
+	// by WatchSlots.
+	duration := c.leaders.slotDuration()
+	for _, w := range blockTimeWindows {
+		d, ok := c.blockTimes.slotDuration(w.duration)
+		if !ok {
+			slotDuration.DeleteLabelValues("block_time", w.label)
+			continue
+		}
+		slotDuration.WithLabelValues("block_time", w.label).Set(d.Seconds())
+		duration = d
+	}
+
+	remaining := info.SlotsInEpoch - info.SlotIndex
+	epochEnd := now.Add(time.Duration(remaining) * duration)
+	epochProjectedEnd.Set(float64(epochEnd.UnixNano()) / 1e9)
+	return nil
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBlockTimeSlotDuration(t *testing.T) {
+	start := time.Unix(1600000000, 0)
+	// step is the time between two samples, and the slots and seconds of block time they advance by.
+	type step struct {
+		after      time.Duration
+		slots      int64
+		blockTimes int64
+	}
+
+	for _, tt := range []struct {
+		name   string
+		steps  []step
+		window time.Duration
+
+		want time.Duration
+		ok   bool
+	}{
+		{
+			name:   "single sample",
+			window: time.Minute,
+		},
+		{
+			name:   "window not covered",
+			steps:  []step{{30 * time.Second, 75, 30}},
+			window: time.Minute,
+		},
+		{
+			name:   "window covered exactly",
+			steps:  []step{{30 * time.Second, 75, 30}, {30 * time.Second, 75, 30}},
+			window: time.Minute,
+			want:   400 * time.Millisecond,
+			ok:     true,
+		},
+		{
+			name:   "base is the newest sample at the start of the window",
+			steps:  []step{{30 * time.Second, 30, 30}, {30 * time.Second, 75, 30}, {30 * time.Second, 60, 30}},
+			window: time.Minute,
+			// 60s over 135 slots, leaving out the slow first step.
+			want: 60 * time.Second / 135,
+			ok:   true,
+		},
+		{
+			name:   "sampling later than the window",
+			steps:  []step{{90 * time.Second, 200, 80}},
+			window: time.Minute,
+			want:   400 * time.Millisecond,
+			ok:     true,
+		},
+		{
+			name:   "block time not advancing",
+			steps:  []step{{time.Minute, 150, 0}},
+			window: time.Minute,
+		},
+		{
+			name:   "slot going backwards",
+			steps:  []step{{time.Minute, -10, 60}},
+			window: time.Minute,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var s blockTimeSampler
+			sample := blockTimeSample{slot: 1000, blockTime: start.Unix(), at: start}
+			s.add(sample)
+			for _, st := range tt.steps {
+				sample.at = sample.at.Add(st.after)
+				sample.slot += st.slots
+				sample.blockTime += st.blockTimes
+				s.add(sample)
+			}
+
+			got, ok := s.slotDuration(tt.window)
+			if ok != tt.ok || got != tt.want {
+				t.Errorf("slotDuration(%v) = %v, %v; want %v, %v", tt.window, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestBlockTimeSamplerAdd(t *testing.T) {
+	start := time.Unix(1600000000, 0)
+	var s blockTimeSampler
+	// A sample every 10 minutes for two hours.
+	for i := int64(0); i <= 12; i++ {
+		s.add(blockTimeSample{slot: i * 1500, blockTime: start.Unix() + i*600, at: start.Add(time.Duration(i) * 10 * time.Minute)})
+	}
+
+	// The samples of the last hour are kept, along with the one at its start as the base of the 1h window.
+	if len(s.samples) != 7 || !s.samples[0].at.Equal(start.Add(time.Hour)) {
+		t.Fatalf("kept %d samples from %v, want 7 from %v", len(s.samples), s.samples[0].at, start.Add(time.Hour))
+	}
+	for _, w := range blockTimeWindows {
+		if d, ok := s.slotDuration(w.duration); !ok || d != 400*time.Millisecond {
+			t.Errorf("slotDuration(%s) = %v, %v; want 400ms", w.label, d, ok)
+		}
+	}
+}
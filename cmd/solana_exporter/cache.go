@@ -0,0 +1,235 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"k8s.io/klog/v2"
+)
+
+// cachedCollector serves the metrics of the collectors added to it from snapshots taken in the background, so that
+// scrapes neither wait for nor add load to the RPC node.
+type cachedCollector struct {
+	refreshers []*refresher
+	started    time.Time
+
+	duration    *prometheus.Desc
+	success     *prometheus.Desc
+	errors      *prometheus.Desc
+	lastSuccess *prometheus.Desc
+	staleness   *prometheus.Desc
+}
+
+// maxStaleRefreshes is the number of refresh intervals after which a snapshot that could not be replaced is no longer
+// served, so that a collector whose node is down does not report its last values indefinitely.
+const maxStaleRefreshes = 3
+
+// refresher periodically collects the metrics of a single collector. Metrics that failed are dropped from the snapshot
+// and never served. If every metric failed, for example because the node is down, the previous snapshot is kept until
+// it is older than maxStaleRefreshes intervals.
+type refresher struct {
+	name      string
+	collector prometheus.Collector
+	// Refresh interval, or zero for the default one passed to Run.
+	interval time.Duration
+
+	mu          sync.RWMutex
+	metrics     []prometheus.Metric
+	lastSuccess time.Time
+	// Outcome of the last refresh, unset before the first one completed.
+	refreshed bool
+	errors    int
+	duration  time.Duration
+}
+
+func NewCachedCollector() *cachedCollector {
+	return &cachedCollector{
+		started: time.Now(),
+		duration: prometheus.NewDesc(
+			"solana_exporter_collector_duration_seconds",
+			"Duration of the last refresh of a collector",
+			[]string{"collector"}, nil),
+		success: prometheus.NewDesc(
+			"solana_exporter_collector_success",
+			"Whether the last refresh of a collector succeeded for every metric",
+			[]string{"collector"}, nil),
+		errors: prometheus.NewDesc(
+			"solana_exporter_collector_errors",
+			"Number of metrics that failed in the last refresh of a collector and were left out of its snapshot",
+			[]string{"collector"}, nil),
+		lastSuccess: prometheus.NewDesc(
+			"solana_exporter_collector_last_success_timestamp_seconds",
+			"Time the cached metrics of a collector were last replaced by a new snapshot",
+			[]string{"collector"}, nil),
+		staleness: prometheus.NewDesc(
+			"solana_exporter_collector_staleness_seconds",
+			"Age of the cached metrics of a collector, or time since startup if it was never refreshed successfully",
+			[]string{"collector"}, nil),
+	}
+}
+
+// Add registers collector under name. It must be called before Run.
+func (c *cachedCollector) Add(name string, collector prometheus.Collector) {
+	c.refreshers = append(c.refreshers, &refresher{name: name, collector: collector})
+}
+
+// SetIntervals overrides the refresh interval of the collectors named in intervals. It must be called before Run.
+func (c *cachedCollector) SetIntervals(intervals map[string]time.Duration) error {
+	for name, interval := range intervals {
+		found := false
+		for _, r := range c.refreshers {
+			if r.name == name {
+				r.interval, found = interval, true
+			}
+		}
+		if !found {
+			return fmt.Errorf("unknown collector %q", name)
+		}
+	}
+	return nil
+}
+
+// Run refreshes every collector concurrently, each at its own interval or else at interval.
+func (c *cachedCollector) Run(interval time.Duration) {
+	for _, r := range c.refreshers {
+		if r.interval == 0 {
+			r.interval = interval
+		}
+		go func(r *refresher) {
+			ticker := time.NewTicker(r.interval)
+			defer ticker.Stop()
+			for {
+				r.refresh()
+				<-ticker.C
+			}
+		}(r)
+	}
+}
+
+// parseIntervals parses a comma-separated list of collector=duration pairs, such as "validators=30s,supply=5m".
+func parseIntervals(s string) (map[string]time.Duration, error) {
+	intervals := make(map[string]time.Duration)
+	if s == "" {
+		return intervals, nil
+	}
+	for _, pair := range strings.Split(s, ",") {
+		i := strings.Index(pair, "=")
+		if i < 0 {
+			return nil, fmt.Errorf("invalid refresh interval %q: expected collector=duration", pair)
+		}
+		name := strings.TrimSpace(pair[:i])
+		interval, err := time.ParseDuration(strings.TrimSpace(pair[i+1:]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid refresh interval of %s: %w", name, err)
+		}
+		if interval <= 0 {
+			return nil, fmt.Errorf("invalid refresh interval of %s: must be positive", name)
+		}
+		if _, ok := intervals[name]; ok {
+			return nil, fmt.Errorf("refresh interval of %s set twice", name)
+		}
+		intervals[name] = interval
+	}
+	return intervals, nil
+}
+
+func (c *cachedCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, r := range c.refreshers {
+		r.collector.Describe(ch)
+	}
+	ch <- c.duration
+	ch <- c.success
+	ch <- c.errors
+	ch <- c.lastSuccess
+	ch <- c.staleness
+}
+
+func (c *cachedCollector) Collect(ch chan<- prometheus.Metric) {
+	now := time.Now()
+	for _, r := range c.refreshers {
+		r.mu.RLock()
+		if !r.expired(now) {
+			for _, m := range r.metrics {
+				ch <- m
+			}
+		}
+		lastSuccess := r.lastSuccess
+		refreshed, errs, duration := r.refreshed, r.errors, r.duration
+		r.mu.RUnlock()
+
+		if refreshed {
+			var success float64
+			if errs == 0 {
+				success = 1
+			}
+			ch <- prometheus.MustNewConstMetric(c.duration, prometheus.GaugeValue, duration.Seconds(), r.name)
+			ch <- prometheus.MustNewConstMetric(c.success, prometheus.GaugeValue, success, r.name)
+			ch <- prometheus.MustNewConstMetric(c.errors, prometheus.GaugeValue, float64(errs), r.name)
+		}
+
+		staleness := now.Sub(c.started)
+		var timestamp float64
+		if !lastSuccess.IsZero() {
+			staleness = now.Sub(lastSuccess)
+			timestamp = float64(lastSuccess.UnixNano()) / 1e9
+		}
+		ch <- prometheus.MustNewConstMetric(c.lastSuccess, prometheus.GaugeValue, timestamp, r.name)
+		ch <- prometheus.MustNewConstMetric(c.staleness, prometheus.GaugeValue, staleness.Seconds(), r.name)
+	}
+}
+
+// expired reports whether the snapshot is too old to be served at now. r.mu must be held.
+func (r *refresher) expired(now time.Time) bool {
+	return r.interval > 0 && now.Sub(r.lastSuccess) > maxStaleRefreshes*r.interval
+}
+
+func (r *refresher) refresh() {
+	start := time.Now()
+	ch := make(chan prometheus.Metric)
+	go func() {
+		r.collector.Collect(ch)
+		close(ch)
+	}()
+
+	var (
+		metrics []prometheus.Metric
+		errs    int
+		lastErr error
+	)
+	for m := range ch {
+		// Invalid metrics report their error on Write.
+		if err := m.Write(&dto.Metric{}); err != nil {
+			errs++
+			lastErr = err
+			continue
+		}
+		metrics = append(metrics, m)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.refreshed = true
+	r.errors = errs
+	r.duration = time.Since(start)
+
+	if lastErr != nil && len(metrics) == 0 {
+		if r.expired(time.Now()) {
+			klog.Warningf("failed to refresh %s collector, previous snapshot expired (%d errors): %v", r.name, errs, lastErr)
+			r.metrics = nil
+			return
+		}
+		klog.Warningf("failed to refresh %s collector, keeping previous snapshot (%d errors): %v", r.name, errs, lastErr)
+		return
+	}
+	if lastErr != nil {
+		klog.Warningf("failed to collect %d metrics of %s collector, leaving them out: %v", errs, r.name, lastErr)
+	}
+
+	r.metrics = metrics
+	r.lastSuccess = time.Now()
+}
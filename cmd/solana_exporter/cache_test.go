@@ -0,0 +1,193 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+var testAccountDesc = prometheus.NewDesc("test_account_balance", "Balance of a test account", []string{"account"}, nil)
+
+// testAccounts emits a balance for every account, or an invalid metric for those whose balance is negative.
+func testAccounts(balances map[string]float64) collectorFunc {
+	return func(ch chan<- prometheus.Metric) {
+		for account, balance := range balances {
+			if balance < 0 {
+				ch <- prometheus.NewInvalidMetric(testAccountDesc, errors.New("account not found"))
+				continue
+			}
+			ch <- prometheus.MustNewConstMetric(testAccountDesc, prometheus.GaugeValue, balance, account)
+		}
+	}
+}
+
+// snapshot returns the balances served by r by account.
+func snapshot(t *testing.T, r *refresher) map[string]float64 {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	balances := make(map[string]float64)
+	for _, metric := range r.metrics {
+		var m dto.Metric
+		if err := metric.Write(&m); err != nil {
+			t.Fatal(err)
+		}
+		balances[m.GetLabel()[0].GetValue()] = m.GetGauge().GetValue()
+	}
+	return balances
+}
+
+func TestRefresh(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		// Balances at the first and the second refresh.
+		first, second map[string]float64
+
+		want   map[string]float64
+		errors int
+		// Whether the second refresh replaced the snapshot.
+		replaced bool
+	}{
+		{
+			name:     "success",
+			first:    map[string]float64{"a": 1, "b": 2},
+			second:   map[string]float64{"a": 3, "b": 4},
+			want:     map[string]float64{"a": 3, "b": 4},
+			replaced: true,
+		},
+		{
+			name:     "partial failure drops the failed metrics",
+			first:    map[string]float64{"a": 1, "b": 2, "c": 3},
+			second:   map[string]float64{"a": 3, "b": -1, "c": 5},
+			want:     map[string]float64{"a": 3, "c": 5},
+			errors:   1,
+			replaced: true,
+		},
+		{
+			name:   "failure keeps the previous snapshot",
+			first:  map[string]float64{"a": 1, "b": 2},
+			second: map[string]float64{"a": -1, "b": -1},
+			want:   map[string]float64{"a": 1, "b": 2},
+			errors: 2,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			balances := tt.first
+			r := &refresher{name: "test", collector: collectorFunc(func(ch chan<- prometheus.Metric) {
+				testAccounts(balances)(ch)
+			})}
+
+			r.refresh()
+			firstSuccess := r.lastSuccess
+			if got := snapshot(t, r); !reflect.DeepEqual(got, tt.first) {
+				t.Fatalf("first snapshot %v, want %v", got, tt.first)
+			}
+
+			time.Sleep(time.Millisecond)
+			balances = tt.second
+			r.refresh()
+			if got := snapshot(t, r); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("snapshot %v, want %v", got, tt.want)
+			}
+			if r.errors != tt.errors {
+				t.Errorf("%d errors, want %d", r.errors, tt.errors)
+			}
+			if replaced := r.lastSuccess.After(firstSuccess); replaced != tt.replaced {
+				t.Errorf("snapshot replaced: %v, want %v", replaced, tt.replaced)
+			}
+		})
+	}
+}
+
+func TestParseIntervals(t *testing.T) {
+	for _, tt := range []struct {
+		s       string
+		want    map[string]time.Duration
+		wantErr bool
+	}{
+		{s: "", want: map[string]time.Duration{}},
+		{s: "validators=30s", want: map[string]time.Duration{"validators": 30 * time.Second}},
+		{
+			s:    "validators=30s, supply = 5m",
+			want: map[string]time.Duration{"validators": 30 * time.Second, "supply": 5 * time.Minute},
+		},
+		{s: "validators", wantErr: true},
+		{s: "validators=30", wantErr: true},
+		{s: "validators=0s", wantErr: true},
+		{s: "validators=30s,validators=1m", wantErr: true},
+		{s: "validators=30s,", wantErr: true},
+	} {
+		got, err := parseIntervals(tt.s)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseIntervals(%q): got err %v, want error %v", tt.s, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseIntervals(%q) = %v, want %v", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestSetIntervals(t *testing.T) {
+	cache := NewCachedCollector()
+	cache.Add("validators", testAccounts(nil))
+	cache.Add("supply", testAccounts(nil))
+
+	if err := cache.SetIntervals(map[string]time.Duration{"unknown": time.Minute}); err == nil {
+		t.Error("SetIntervals accepted an unknown collector")
+	}
+	if err := cache.SetIntervals(map[string]time.Duration{"supply": time.Hour}); err != nil {
+		t.Fatalf("SetIntervals failed: %v", err)
+	}
+
+	if got := []time.Duration{cache.refreshers[0].interval, cache.refreshers[1].interval}; got[0] != 0 || got[1] != time.Hour {
+		t.Errorf("intervals %v, want the default for validators and 1h for supply", got)
+	}
+}
+
+func TestSnapshotExpiry(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		// Age of the snapshot when every metric fails.
+		age time.Duration
+
+		served bool
+	}{
+		{name: "recent snapshot", age: 20 * time.Second, served: true},
+		{name: "at the maximum staleness", age: 40 * time.Second, served: true},
+		{name: "expired snapshot", age: 50 * time.Second},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			balances := map[string]float64{"a": 1}
+			cache := NewCachedCollector()
+			cache.Add("test", collectorFunc(func(ch chan<- prometheus.Metric) {
+				testAccounts(balances)(ch)
+			}))
+			r := cache.refreshers[0]
+			r.interval = 15 * time.Second
+
+			r.refresh()
+			r.lastSuccess = r.lastSuccess.Add(-tt.age)
+			balances = map[string]float64{"a": -1}
+			r.refresh()
+
+			ch := make(chan prometheus.Metric, 10)
+			cache.Collect(ch)
+			close(ch)
+			served := false
+			for m := range ch {
+				served = served || m.Desc() == testAccountDesc
+			}
+			if served != tt.served {
+				t.Errorf("snapshot served: %v, want %v", served, tt.served)
+			}
+			if kept := len(snapshot(t, r)) > 0; kept != tt.served {
+				t.Errorf("snapshot kept: %v, want %v", kept, tt.served)
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/certusone/solana_exporter/pkg/rpc"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Label value for nodes that did not advertise a version or feature set, and for stake of validators not in gossip.
+const unknownLabel = "unknown"
+
+var errNoVoteAccounts = errors.New("vote accounts not fetched yet")
+
+// clusterNodesCollector exports an inventory of the nodes in gossip, by software version and feature set, joined with
+// the vote accounts to show how much stake runs each of them. The vote accounts are those last fetched by the
+// validators collector.
+type clusterNodesCollector struct {
+	rpcClient    *rpc.RPCClient
+	config       *configStore
+	voteAccounts func() *rpc.VoteAccounts
+
+	gossipNodes       *prometheus.Desc
+	rpcNodes          *prometheus.Desc
+	nodesByVersion    *prometheus.Desc
+	nodesByFeatureSet *prometheus.Desc
+	stakeByVersion    *prometheus.Desc
+	stakeByFeatureSet *prometheus.Desc
+	validatorInGossip *prometheus.Desc
+}
+
+func NewClusterNodesCollector(client *rpc.RPCClient, config *configStore, voteAccounts func() *rpc.VoteAccounts) *clusterNodesCollector {
+	return &clusterNodesCollector{
+		rpcClient:    client,
+		config:       config,
+		voteAccounts: voteAccounts,
+		gossipNodes: prometheus.NewDesc(
+			"solana_cluster_gossip_nodes",
+			"Number of nodes in gossip",
+			nil, nil),
+		rpcNodes: prometheus.NewDesc(
+			"solana_cluster_rpc_nodes",
+			"Number of nodes in gossip advertising a JSON RPC address",
+			nil, nil),
+		nodesByVersion: prometheus.NewDesc(
+			"solana_cluster_nodes_by_version",
+			"Number of nodes in gossip by software version",
+			[]string{"version"}, nil),
+		nodesByFeatureSet: prometheus.NewDesc(
+			"solana_cluster_nodes_by_feature_set",
+			"Number of nodes in gossip by feature set",
+			[]string{"feature_set"}, nil),
+		stakeByVersion: prometheus.NewDesc(
+			"solana_cluster_stake_by_version",
+			"Activated stake in lamports by software version of the validator's node",
+			[]string{"version"}, nil),
+		stakeByFeatureSet: prometheus.NewDesc(
+			"solana_cluster_stake_by_feature_set",
+			"Activated stake in lamports by feature set of the validator's node",
+			[]string{"feature_set"}, nil),
+		validatorInGossip: prometheus.NewDesc(
+			"solana_validator_in_gossip",
+			"Whether each tracked validator identity is present in gossip",
+			[]string{"nodekey"}, nil),
+	}
+}
+
+func (c *clusterNodesCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.gossipNodes
+}
+
+func (c *clusterNodesCollector) mustClusterNodesMetrics(ch chan<- prometheus.Metric, nodes []rpc.GetClusterNodesResult, accounts *rpc.VoteAccounts) {
+	var (
+		byIdentity        = make(map[string]rpc.GetClusterNodesResult, len(nodes))
+		nodesByVersion    = make(map[string]int)
+		nodesByFeatureSet = make(map[string]int)
+		rpcNodes          int
+	)
+	for _, node := range nodes {
+		byIdentity[node.Pubkey] = node
+		nodesByVersion[nodeVersion(node)]++
+		nodesByFeatureSet[nodeFeatureSet(node)]++
+		if node.RPC != "" {
+			rpcNodes++
+		}
+	}
+
+	stakeByVersion := make(map[string]int64)
+	stakeByFeatureSet := make(map[string]int64)
+	// accounts is shared with the validators collector; appending Delinquent to Current could write to its backing
+	// array concurrently.
+	for _, list := range [][]rpc.VoteAccount{accounts.Current, accounts.Delinquent} {
+		for _, account := range list {
+			version, featureSet := unknownLabel, unknownLabel
+			if node, ok := byIdentity[account.NodePubkey]; ok {
+				version, featureSet = nodeVersion(node), nodeFeatureSet(node)
+			}
+			stakeByVersion[version] += account.ActivatedStake
+			stakeByFeatureSet[featureSet] += account.ActivatedStake
+		}
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.gossipNodes, prometheus.GaugeValue, float64(len(nodes)))
+	ch <- prometheus.MustNewConstMetric(c.rpcNodes, prometheus.GaugeValue, float64(rpcNodes))
+	for version, n := range nodesByVersion {
+		ch <- prometheus.MustNewConstMetric(c.nodesByVersion, prometheus.GaugeValue, float64(n), version)
+	}
+	for featureSet, n := range nodesByFeatureSet {
+		ch <- prometheus.MustNewConstMetric(c.nodesByFeatureSet, prometheus.GaugeValue, float64(n), featureSet)
+	}
+	for version, stake := range stakeByVersion {
+		ch <- prometheus.MustNewConstMetric(c.stakeByVersion, prometheus.GaugeValue, float64(stake), version)
+	}
+	for featureSet, stake := range stakeByFeatureSet {
+		ch <- prometheus.MustNewConstMetric(c.stakeByFeatureSet, prometheus.GaugeValue, float64(stake), featureSet)
+	}
+
+	for _, identity := range c.config.Load().TrackedIdentities {
+		var present float64
+		if _, ok := byIdentity[identity]; ok {
+			present = 1
+		}
+		ch <- prometheus.MustNewConstMetric(c.validatorInGossip, prometheus.GaugeValue, present, identity)
+	}
+}
+
+func (c *clusterNodesCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), httpTimeout)
+	defer cancel()
+
+	nodes, err := c.rpcClient.GetClusterNodes(ctx)
+	accounts := c.voteAccounts()
+	if err == nil && accounts == nil {
+		err = errNoVoteAccounts
+	}
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(c.gossipNodes, err)
+		ch <- prometheus.NewInvalidMetric(c.nodesByVersion, err)
+		ch <- prometheus.NewInvalidMetric(c.stakeByVersion, err)
+		ch <- prometheus.NewInvalidMetric(c.validatorInGossip, err)
+	} else {
+		c.mustClusterNodesMetrics(ch, nodes, accounts)
+	}
+}
+
+func nodeVersion(node rpc.GetClusterNodesResult) string {
+	if node.Version == "" {
+		return unknownLabel
+	}
+	return node.Version
+}
+
+func nodeFeatureSet(node rpc.GetClusterNodesResult) string {
+	if node.FeatureSet == 0 {
+		return unknownLabel
+	}
+	return strconv.FormatInt(node.FeatureSet, 10)
+}
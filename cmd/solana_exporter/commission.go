@@ -0,0 +1,116 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/certusone/solana_exporter/pkg/rpc"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/klog/v2"
+)
+
+// Number of commission changes kept for /api/v1/commission-changes.
+const maxCommissionChanges = 1000
+
+type (
+	// commissionTracker remembers the commission of every vote account to detect changes between refreshes. It serves
+	// the latest changes on GET /api/v1/commission-changes.
+	commissionTracker struct {
+		mu         sync.Mutex
+		commission map[string]int
+		changes    []commissionChange
+	}
+
+	commissionChange struct {
+		VotePubkey    string    `json:"votekey"`
+		NodePubkey    string    `json:"nodekey"`
+		OldCommission int       `json:"old_commission"`
+		NewCommission int       `json:"new_commission"`
+		Epoch         int64     `json:"epoch"`
+		Time          time.Time `json:"time"`
+	}
+)
+
+// observe records the commission of account in epoch, logging and counting it as a change if it differs from the one
+// observed before.
+func (t *commissionTracker) observe(account rpc.VoteAccount, epoch int64, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.commission == nil {
+		t.commission = make(map[string]int)
+	}
+	prev, ok := t.commission[account.VotePubkey]
+	t.commission[account.VotePubkey] = account.Commission
+	if !ok || prev == account.Commission {
+		return
+	}
+
+	klog.Warningf("commission of vote account %s (node %s) changed from %d%% to %d%% in epoch %d",
+		account.VotePubkey, account.NodePubkey, prev, account.Commission, epoch)
+	commissionChangesTotal.WithLabelValues(account.VotePubkey, account.NodePubkey).Inc()
+
+	t.changes = append(t.changes, commissionChange{
+		VotePubkey:    account.VotePubkey,
+		NodePubkey:    account.NodePubkey,
+		OldCommission: prev,
+		NewCommission: account.Commission,
+		Epoch:         epoch,
+		Time:          now,
+	})
+	if len(t.changes) > maxCommissionChanges {
+		t.changes = t.changes[len(t.changes)-maxCommissionChanges:]
+	}
+}
+
+// prune forgets the accounts not in seen.
+func (t *commissionTracker) prune(seen map[string]bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for votePubkey := range t.commission {
+		if !seen[votePubkey] {
+			delete(t.commission, votePubkey)
+		}
+	}
+}
+
+// ServeHTTP lists the commission changes observed since startup, oldest first.
+func (t *commissionTracker) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "only GET requests allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	t.mu.Lock()
+	changes := append([]commissionChange{}, t.changes...)
+	t.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(changes); err != nil {
+		klog.Errorf("failed to write commission changes: %v", err)
+	}
+}
+
+// mustEmitCommissionMetrics exports the commission of every validator and records changes since the previous refresh
+// in the current epoch. If the epoch is unknownEpoch, changes are left to be recorded at the next refresh.
+func (c *solanaCollector) mustEmitCommissionMetrics(ch chan<- prometheus.Metric, response *rpc.VoteAccounts, epoch int64) {
+	accounts := append(response.Current, response.Delinquent...)
+	now := time.Now()
+	seen := make(map[string]bool, len(accounts))
+
+	for _, account := range accounts {
+		ch <- prometheus.MustNewConstMetric(c.validatorCommission, prometheus.GaugeValue,
+			float64(account.Commission), account.VotePubkey, account.NodePubkey)
+		if epoch != unknownEpoch {
+			c.commission.observe(account, epoch, now)
+			seen[account.VotePubkey] = true
+		}
+	}
+	if epoch != unknownEpoch {
+		c.commission.prune(seen)
+	}
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"sort"
+
+	"github.com/certusone/solana_exporter/pkg/rpc"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// nakamotoCoefficient returns the minimum number of validators holding more than a third of the total stake, and the
+// stake they hold. stakes must be sorted in descending order.
+func nakamotoCoefficient(stakes []int64, total int64) (int, int64) {
+	var held int64
+	for i, stake := range stakes {
+		held += stake
+		if 3*held > total {
+			return i + 1, held
+		}
+	}
+	return len(stakes), held
+}
+
+// giniCoefficient returns the Gini coefficient of stakes, from 0 if all are equal to 1 if a single one holds
+// everything. stakes must be sorted in descending order.
+func giniCoefficient(stakes []int64, total int64) float64 {
+	n := len(stakes)
+	if n == 0 || total == 0 {
+		return 0
+	}
+	// With ascending ranks i = 1..n: G = 2 * sum(i * x_i) / (n * sum(x)) - (n + 1) / n
+	var weighted float64
+	for i, stake := range stakes {
+		weighted += float64(n-i) * float64(stake)
+	}
+	return 2*weighted/(float64(n)*float64(total)) - float64(n+1)/float64(n)
+}
+
+// mustEmitConcentrationMetrics exports how concentrated the activated stake is among validators.
+func (c *solanaCollector) mustEmitConcentrationMetrics(ch chan<- prometheus.Metric, response *rpc.VoteAccounts) {
+	accounts := append(response.Current, response.Delinquent...)
+
+	var activeStake, delinquentStake int64
+	for _, account := range response.Current {
+		activeStake += account.ActivatedStake
+	}
+	for _, account := range response.Delinquent {
+		delinquentStake += account.ActivatedStake
+	}
+	total := activeStake + delinquentStake
+
+	ch <- prometheus.MustNewConstMetric(c.clusterStake, prometheus.GaugeValue, float64(activeStake), "active")
+	ch <- prometheus.MustNewConstMetric(c.clusterStake, prometheus.GaugeValue, float64(delinquentStake), "delinquent")
+	if total == 0 {
+		return
+	}
+
+	stakes := make([]int64, 0, len(accounts))
+	for _, account := range accounts {
+		stakes = append(stakes, account.ActivatedStake)
+		ch <- prometheus.MustNewConstMetric(c.validatorStakeShare, prometheus.GaugeValue,
+			100*float64(account.ActivatedStake)/float64(total), account.VotePubkey, account.NodePubkey)
+	}
+	sort.Slice(stakes, func(i, j int) bool { return stakes[i] > stakes[j] })
+
+	nakamoto, superminorityStake := nakamotoCoefficient(stakes, total)
+	ch <- prometheus.MustNewConstMetric(c.clusterNakamotoCoefficient, prometheus.GaugeValue, float64(nakamoto))
+	ch <- prometheus.MustNewConstMetric(c.clusterSuperminorityStake, prometheus.GaugeValue, float64(superminorityStake))
+	ch <- prometheus.MustNewConstMetric(c.clusterStakeGini, prometheus.GaugeValue, giniCoefficient(stakes, total))
+}
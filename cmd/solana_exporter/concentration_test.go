@@ -0,0 +1,59 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func stakeSum(stakes []int64) int64 {
+	var total int64
+	for _, stake := range stakes {
+		total += stake
+	}
+	return total
+}
+
+func TestNakamotoCoefficient(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		stakes []int64
+		want   int
+		held   int64
+	}{
+		{"single majority", []int64{50, 30, 20}, 1, 50},
+		{"just over a third", []int64{34, 33, 33}, 1, 34},
+		{"exactly a third is not enough", []int64{30, 30, 30}, 2, 60},
+		{"even", []int64{10, 10, 10, 10, 10, 10, 10, 10, 10, 10}, 4, 40},
+		{"long tail", []int64{20, 10, 5, 5, 5, 5, 5, 5}, 2, 30},
+		{"no stake", nil, 0, 0},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			n, held := nakamotoCoefficient(tt.stakes, stakeSum(tt.stakes))
+			if n != tt.want || held != tt.held {
+				t.Errorf("nakamotoCoefficient(%v) = %d, %d; want %d, %d", tt.stakes, n, held, tt.want, tt.held)
+			}
+		})
+	}
+}
+
+func TestGiniCoefficient(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		stakes []int64
+		want   float64
+	}{
+		{"equal", []int64{10, 10, 10, 10}, 0},
+		{"single validator", []int64{10}, 0},
+		{"one holds everything", []int64{100, 0, 0, 0}, 0.75},
+		{"two validators", []int64{3, 1}, 0.25},
+		{"three validators", []int64{3, 2, 1}, 2.0 / 9},
+		{"no validators", nil, 0},
+		{"no stake", []int64{0, 0}, 0},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := giniCoefficient(tt.stakes, stakeSum(tt.stakes)); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("giniCoefficient(%v) = %v, want %v", tt.stakes, got, tt.want)
+			}
+		})
+	}
+}
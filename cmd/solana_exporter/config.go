@@ -0,0 +1,256 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/certusone/solana_exporter/pkg/rpc"
+	"k8s.io/klog/v2"
+)
+
+const (
+	base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+	// Port of the node_ip entries that do not specify one.
+	defaultRPCPort = "8899"
+)
+
+type (
+	// Config lists the accounts monitored by the account collectors. It is loaded from the file passed with -config.
+	Config struct {
+		// RPC nodes added to the -rpcURI endpoint pool at startup, as host, host:port or URL.
+		NodeIPs []string `json:"node_ip"`
+		// Stake accounts exported by the stake activation collector.
+		StakeAccounts []string `json:"stake_account_pubkey"`
+		// Token accounts exported by the token account balance collector.
+		TokenAccounts []string `json:"token_account_pubkey"`
+		// Owner and mint pairs exported by the token accounts by owner collector.
+		TokenOwners []PubkeyMint `json:"account_owner_pubkey_mint"`
+		// Delegate and mint pairs queried by the slot watcher.
+		TokenDelegates []PubkeyMint `json:"token_delegate_pubkey_mint"`
+		// Mints exported by the token supply collector.
+		TokenMints []string `json:"token_mint_pubkey"`
+		// Accounts exported by the base64 and jsonParsed account info collectors.
+		AccountInfos []string `json:"account_info_pubkey"`
+		// Accounts exported by the balance collector. If empty, it falls back to the first 100 vote accounts.
+		AccountBalances []string `json:"account_balance_pubkey"`
+		// Validator identities whose upcoming leader slots are exported.
+		TrackedIdentities []string `json:"tracked_identity_pubkey"`
+	}
+
+	// configStore holds the current config. Reloads swap it atomically, so collectors always see a complete,
+	// validated config.
+	configStore struct {
+		path   string
+		mu     sync.Mutex
+		config atomic.Value
+	}
+
+	// PubkeyMint is an account paired with a token mint, written as a two-element array in the config file.
+	PubkeyMint struct {
+		Pubkey string
+		Mint   string
+	}
+)
+
+func (p *PubkeyMint) UnmarshalJSON(data []byte) error {
+	var pair []string
+	if err := json.Unmarshal(data, &pair); err != nil {
+		return err
+	}
+	if len(pair) != 2 {
+		return fmt.Errorf("expected [pubkey, mint] pair, got %d elements", len(pair))
+	}
+	p.Pubkey, p.Mint = pair[0], pair[1]
+	return nil
+}
+
+// loadConfig reads and validates the config file at path.
+func loadConfig(path string) (*Config, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg Config
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	if err := cfg.validate(); err != nil {
+		return nil, fmt.Errorf("invalid config %s: %w", path, err)
+	}
+
+	return &cfg, nil
+}
+
+// validate checks every pubkey. Entries listed twice are rejected, as they would be exported as duplicate series.
+func (cfg *Config) validate() error {
+	lists := []struct {
+		field   string
+		pubkeys []string
+	}{
+		{"stake_account_pubkey", cfg.StakeAccounts},
+		{"token_account_pubkey", cfg.TokenAccounts},
+		{"token_mint_pubkey", cfg.TokenMints},
+		{"account_info_pubkey", cfg.AccountInfos},
+		{"account_balance_pubkey", cfg.AccountBalances},
+		{"tracked_identity_pubkey", cfg.TrackedIdentities},
+	}
+	for _, l := range lists {
+		seen := make(map[string]bool, len(l.pubkeys))
+		for _, pubkey := range l.pubkeys {
+			if err := validatePubkey(pubkey); err != nil {
+				return fmt.Errorf("%s: %w", l.field, err)
+			}
+			if seen[pubkey] {
+				return fmt.Errorf("%s: %q listed twice", l.field, pubkey)
+			}
+			seen[pubkey] = true
+		}
+	}
+
+	if _, err := cfg.nodeEndpoints(); err != nil {
+		return err
+	}
+
+	pairs := []struct {
+		field string
+		pairs []PubkeyMint
+	}{
+		{"account_owner_pubkey_mint", cfg.TokenOwners},
+		{"token_delegate_pubkey_mint", cfg.TokenDelegates},
+	}
+	for _, l := range pairs {
+		seen := make(map[PubkeyMint]bool, len(l.pairs))
+		for _, pair := range l.pairs {
+			if err := validatePubkey(pair.Pubkey); err != nil {
+				return fmt.Errorf("%s: %w", l.field, err)
+			}
+			if err := validatePubkey(pair.Mint); err != nil {
+				return fmt.Errorf("%s: %w", l.field, err)
+			}
+			if seen[pair] {
+				return fmt.Errorf("%s: [%q, %q] listed twice", l.field, pair.Pubkey, pair.Mint)
+			}
+			seen[pair] = true
+		}
+	}
+
+	return nil
+}
+
+// nodeEndpoints returns the RPC URIs of the node_ip entries. Entries without a scheme use http, and port
+// defaultRPCPort if they do not specify one.
+func (cfg *Config) nodeEndpoints() ([]string, error) {
+	uris := make([]string, 0, len(cfg.NodeIPs))
+	seen := make(map[string]bool, len(cfg.NodeIPs))
+	for _, node := range cfg.NodeIPs {
+		if node == "" {
+			return nil, errors.New("node_ip: empty entry")
+		}
+		uri := node
+		if !strings.Contains(node, "://") {
+			if _, _, err := net.SplitHostPort(node); err != nil {
+				node = net.JoinHostPort(node, defaultRPCPort)
+			}
+			uri = "http://" + node
+		}
+		if _, err := rpc.ParseEndpoints(uri); err != nil {
+			return nil, fmt.Errorf("node_ip: %w", err)
+		}
+		if seen[uri] {
+			return nil, fmt.Errorf("node_ip: %q listed twice", uri)
+		}
+		seen[uri] = true
+		uris = append(uris, uri)
+	}
+	return uris, nil
+}
+
+// validatePubkey checks that pubkey looks like a base58 encoded 32 byte key.
+func validatePubkey(pubkey string) error {
+	if len(pubkey) < 32 || len(pubkey) > 44 {
+		return fmt.Errorf("%q is not a valid pubkey: length %d", pubkey, len(pubkey))
+	}
+	if i := strings.IndexFunc(pubkey, func(r rune) bool { return !strings.ContainsRune(base58Alphabet, r) }); i >= 0 {
+		return fmt.Errorf("%q is not a valid pubkey: invalid character %q", pubkey, pubkey[i])
+	}
+	return nil
+}
+
+// newConfigStore loads the config at path. An empty path yields an empty config that cannot be reloaded.
+func newConfigStore(path string) (*configStore, error) {
+	s := &configStore{path: path}
+	s.config.Store(&Config{})
+	if path == "" {
+		return s, nil
+	}
+
+	return s, s.Reload()
+}
+
+// Load returns the current config. It must not be modified.
+func (s *configStore) Load() *Config {
+	return s.config.Load().(*Config)
+}
+
+// Reload reads and validates the config file and swaps it in. The current config is kept if that fails.
+func (s *configStore) Reload() error {
+	if s.path == "" {
+		return errors.New("no config file specified with -config")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cfg, err := loadConfig(s.path)
+	if err != nil {
+		configLastReloadSuccessful.Set(0)
+		return err
+	}
+
+	s.config.Store(cfg)
+	configLastReloadSuccessful.Set(1)
+	configLastReloadSuccessTimestamp.Set(float64(time.Now().Unix()))
+	klog.Infof("loaded config from %s", s.path)
+	return nil
+}
+
+// WatchSIGHUP reloads the config whenever the process receives SIGHUP.
+func (s *configStore) WatchSIGHUP() {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	for range hup {
+		if err := s.Reload(); err != nil {
+			klog.Errorf("failed to reload config: %v", err)
+		}
+	}
+}
+
+// ServeHTTP reloads the config on POST requests.
+func (s *configStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "only POST requests allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := s.Reload(); err != nil {
+		klog.Errorf("failed to reload config: %v", err)
+		http.Error(w, fmt.Sprintf("failed to reload config: %v", err), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestConfigValidate(t *testing.T) {
+	const (
+		a = "Vote111111111111111111111111111111111111111"
+		b = "Stake11111111111111111111111111111111111111"
+	)
+
+	for _, tt := range []struct {
+		name string
+		cfg  Config
+		err  string
+	}{
+		{name: "empty"},
+		{
+			name: "valid",
+			cfg: Config{
+				StakeAccounts: []string{a, b},
+				TokenMints:    []string{a},
+				TokenOwners:   []PubkeyMint{{a, b}, {a, a}, {b, b}},
+			},
+		},
+		{
+			name: "malformed pubkey",
+			cfg:  Config{TokenAccounts: []string{"abc"}},
+			err:  "token_account_pubkey",
+		},
+		{
+			name: "invalid character",
+			cfg:  Config{AccountInfos: []string{strings.Repeat("0", 32)}},
+			err:  "invalid character",
+		},
+		{
+			name: "pubkey listed twice",
+			cfg:  Config{StakeAccounts: []string{a, b, a}},
+			err:  "stake_account_pubkey",
+		},
+		{
+			name: "pair listed twice",
+			cfg:  Config{TokenOwners: []PubkeyMint{{a, b}, {a, b}}},
+			err:  "account_owner_pubkey_mint",
+		},
+		{
+			name: "malformed mint",
+			cfg:  Config{TokenDelegates: []PubkeyMint{{a, "abc"}}},
+			err:  "token_delegate_pubkey_mint",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.validate()
+			if tt.err == "" {
+				if err != nil {
+					t.Errorf("validate() = %v, want nil", err)
+				}
+			} else if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("validate() = %v, want error containing %q", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestNodeEndpoints(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		nodes []string
+		want  []string
+		err   bool
+	}{
+		{name: "none", want: []string{}},
+		{
+			name:  "hosts",
+			nodes: []string{"10.0.0.1", "rpc.example.com:8999", "::1"},
+			want:  []string{"http://10.0.0.1:8899", "http://rpc.example.com:8999", "http://[::1]:8899"},
+		},
+		{
+			name:  "URLs",
+			nodes: []string{"https://rpc.example.com/token", "http://10.0.0.1:8899"},
+			want:  []string{"https://rpc.example.com/token", "http://10.0.0.1:8899"},
+		},
+		{name: "unsupported scheme", nodes: []string{"ws://10.0.0.1:8900"}, err: true},
+		{name: "empty", nodes: []string{""}, err: true},
+		{name: "listed twice", nodes: []string{"10.0.0.1", "10.0.0.1:8899"}, err: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Config{NodeIPs: tt.nodes}
+			got, err := cfg.nodeEndpoints()
+			if (err != nil) != tt.err {
+				t.Fatalf("nodeEndpoints() error = %v, want error: %v", err, tt.err)
+			}
+			if !tt.err && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("nodeEndpoints() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/certusone/solana_exporter/pkg/rpc"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Quantiles of the current epoch's vote credits exported for the whole cluster.
+var creditQuantiles = []float64{0.1, 0.25, 0.5, 0.75, 0.9, 0.99}
+
+// creditTracker remembers the total vote credits of every vote account, to export the credits earned between
+// refreshes.
+type creditTracker struct {
+	mu      sync.Mutex
+	credits map[string]int64
+}
+
+// delta records the total credits of votePubkey and returns how many were earned since the previous call. It returns
+// false on the first call for an account, or if its credits went down.
+func (t *creditTracker) delta(votePubkey string, credits int64) (int64, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.credits == nil {
+		t.credits = make(map[string]int64)
+	}
+	prev, ok := t.credits[votePubkey]
+	t.credits[votePubkey] = credits
+	if !ok || credits < prev {
+		return 0, false
+	}
+	return credits - prev, true
+}
+
+// prune forgets the accounts not in seen.
+func (t *creditTracker) prune(seen map[string]bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for votePubkey := range t.credits {
+		if !seen[votePubkey] {
+			delete(t.credits, votePubkey)
+		}
+	}
+}
+
+// epochCredits returns the credits account earned in epoch, and its total credits at the end of its latest epoch.
+func epochCredits(account rpc.VoteAccount, epoch int64) (earned, total int64) {
+	for _, c := range account.EpochCredits {
+		// [epoch, credits, previousCredits]
+		if len(c) != 3 {
+			continue
+		}
+		if c[0] == epoch {
+			earned = c[1] - c[2]
+		}
+		if c[1] > total {
+			total = c[1]
+		}
+	}
+	return earned, total
+}
+
+// latestCreditEpoch returns the latest epoch any of accounts earned credits in, which is the current epoch unless it
+// just started.
+func latestCreditEpoch(accounts []rpc.VoteAccount) int64 {
+	var epoch int64
+	for _, account := range accounts {
+		for _, credits := range account.EpochCredits {
+			if len(credits) == 3 && credits[0] > epoch {
+				epoch = credits[0]
+			}
+		}
+	}
+	return epoch
+}
+
+// quantile returns the q-quantile of sorted, interpolating linearly between closest ranks.
+func quantile(sorted []int64, q float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	pos := q * float64(len(sorted)-1)
+	i := int(pos)
+	if i+1 >= len(sorted) {
+		return float64(sorted[len(sorted)-1])
+	}
+	return float64(sorted[i]) + (pos-float64(i))*float64(sorted[i+1]-sorted[i])
+}
+
+// creditRank returns the rank of credits among all, starting at 1. Equal credits share a rank.
+func creditRank(all []int64, credits int64) int {
+	rank := 1
+	for _, other := range all {
+		if other > credits {
+			rank++
+		}
+	}
+	return rank
+}
+
+// mustEmitCreditMetrics exports the vote credits of every validator in the current epoch, along with cluster-wide
+// statistics and the rank of the tracked validators. If epoch is unknownEpoch, the latest epoch any validator earned
+// credits in is used instead.
+func (c *solanaCollector) mustEmitCreditMetrics(ch chan<- prometheus.Metric, response *rpc.VoteAccounts, epoch int64) {
+	accounts := append(response.Current, response.Delinquent...)
+	if epoch == unknownEpoch {
+		epoch = latestCreditEpoch(accounts)
+	}
+
+	var (
+		current = make([]int64, len(accounts))
+		seen    = make(map[string]bool, len(accounts))
+		sum     int64
+	)
+	for i, account := range accounts {
+		earned, total := epochCredits(account, epoch)
+		previous, _ := epochCredits(account, epoch-1)
+		current[i] = earned
+		sum += earned
+		seen[account.VotePubkey] = true
+
+		ch <- prometheus.MustNewConstMetric(c.validatorEpochCredits, prometheus.GaugeValue,
+			float64(earned), account.VotePubkey, account.NodePubkey)
+		ch <- prometheus.MustNewConstMetric(c.validatorPreviousEpochCredits, prometheus.GaugeValue,
+			float64(previous), account.VotePubkey, account.NodePubkey)
+		if delta, ok := c.credits.delta(account.VotePubkey, total); ok {
+			ch <- prometheus.MustNewConstMetric(c.validatorCreditsDelta, prometheus.GaugeValue,
+				float64(delta), account.VotePubkey, account.NodePubkey)
+		}
+	}
+	c.credits.prune(seen)
+
+	if len(accounts) == 0 {
+		return
+	}
+
+	tracked := make(map[string]bool)
+	for _, identity := range c.config.Load().TrackedIdentities {
+		tracked[identity] = true
+	}
+	for i, account := range accounts {
+		if !tracked[account.NodePubkey] {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(c.validatorCreditsRank, prometheus.GaugeValue,
+			float64(creditRank(current, current[i])), account.VotePubkey, account.NodePubkey)
+	}
+
+	sorted := append([]int64(nil), current...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	ch <- prometheus.MustNewConstMetric(c.clusterCreditsAverage, prometheus.GaugeValue,
+		float64(sum)/float64(len(sorted)))
+	for _, q := range creditQuantiles {
+		ch <- prometheus.MustNewConstMetric(c.clusterCreditsQuantile, prometheus.GaugeValue,
+			quantile(sorted, q), strconv.FormatFloat(q, 'f', -1, 64))
+	}
+}
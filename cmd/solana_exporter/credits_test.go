@@ -0,0 +1,174 @@
+package main
+
+import (
+	"math"
+	"testing"
+
+	"github.com/certusone/solana_exporter/pkg/rpc"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func TestEpochCredits(t *testing.T) {
+	account := rpc.VoteAccount{EpochCredits: [][]int64{
+		{210, 1000, 600},
+		{211, 1350, 1000},
+		{212, 1500, 1350},
+		// Malformed entries are ignored.
+		{213, 99999},
+	}}
+
+	for _, tt := range []struct {
+		epoch  int64
+		earned int64
+	}{
+		{212, 150},
+		{211, 350},
+		{210, 400},
+		{209, 0},
+		{213, 0},
+	} {
+		earned, total := epochCredits(account, tt.epoch)
+		if earned != tt.earned || total != 1500 {
+			t.Errorf("epochCredits(%d) = %d, %d; want %d, 1500", tt.epoch, earned, total, tt.earned)
+		}
+	}
+}
+
+func TestLatestCreditEpoch(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		accounts []rpc.VoteAccount
+		want     int64
+	}{
+		{name: "no accounts", want: 0},
+		{
+			name: "latest of any account",
+			accounts: []rpc.VoteAccount{
+				{EpochCredits: [][]int64{{210, 10, 0}, {211, 20, 10}}},
+				{EpochCredits: [][]int64{{211, 10, 0}, {212, 30, 10}}},
+				{},
+			},
+			want: 212,
+		},
+		{
+			name:     "malformed entries ignored",
+			accounts: []rpc.VoteAccount{{EpochCredits: [][]int64{{210, 10, 0}, {300}}}},
+			want:     210,
+		},
+	} {
+		if got := latestCreditEpoch(tt.accounts); got != tt.want {
+			t.Errorf("%s: latestCreditEpoch = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestQuantile(t *testing.T) {
+	sorted := []int64{10, 20, 30, 40, 50}
+	for _, tt := range []struct {
+		sorted []int64
+		q      float64
+		want   float64
+	}{
+		{sorted, 0, 10},
+		{sorted, 0.5, 30},
+		{sorted, 1, 50},
+		{sorted, 0.1, 14},
+		{sorted, 0.9, 46},
+		{sorted, 0.99, 49.6},
+		{[]int64{7}, 0.5, 7},
+		{[]int64{0, 100}, 0.25, 25},
+		{nil, 0.5, 0},
+	} {
+		if got := quantile(tt.sorted, tt.q); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("quantile(%v, %v) = %v, want %v", tt.sorted, tt.q, got, tt.want)
+		}
+	}
+}
+
+func TestCreditRank(t *testing.T) {
+	all := []int64{300, 100, 300, 200, 0}
+	for _, tt := range []struct {
+		credits int64
+		want    int
+	}{
+		{300, 1},
+		{200, 3},
+		{100, 4},
+		{0, 5},
+	} {
+		if got := creditRank(all, tt.credits); got != tt.want {
+			t.Errorf("creditRank(%d) = %d, want %d", tt.credits, got, tt.want)
+		}
+	}
+}
+
+func TestCreditTracker(t *testing.T) {
+	var tracker creditTracker
+	for _, tt := range []struct {
+		votePubkey string
+		credits    int64
+		want       int64
+		ok         bool
+	}{
+		{"a", 100, 0, false},
+		{"a", 130, 30, true},
+		{"b", 50, 0, false},
+		{"a", 130, 0, true},
+		// A reset vote account starts over.
+		{"a", 10, 0, false},
+		{"a", 25, 15, true},
+	} {
+		delta, ok := tracker.delta(tt.votePubkey, tt.credits)
+		if delta != tt.want || ok != tt.ok {
+			t.Errorf("delta(%s, %d) = %d, %v; want %d, %v", tt.votePubkey, tt.credits, delta, ok, tt.want, tt.ok)
+		}
+	}
+
+	tracker.prune(map[string]bool{"a": true})
+	if _, ok := tracker.credits["b"]; ok {
+		t.Error("prune kept an account that was not seen")
+	}
+	if _, ok := tracker.delta("a", 40); !ok {
+		t.Error("prune forgot an account that was seen")
+	}
+}
+
+func TestCreditMetricsEpoch(t *testing.T) {
+	config, _ := newConfigStore("")
+	response := &rpc.VoteAccounts{Current: []rpc.VoteAccount{
+		{VotePubkey: "a", EpochCredits: [][]int64{{9, 100, 0}, {10, 250, 100}}},
+	}}
+
+	for _, tt := range []struct {
+		name              string
+		epoch             int64
+		current, previous float64
+	}{
+		// Right after the boundary, no credits were earned in the new epoch yet.
+		{"epoch from getEpochInfo", 11, 0, 150},
+		{"unknown epoch", unknownEpoch, 150, 100},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewSolanaCollector(nil, config)
+			ch := make(chan prometheus.Metric, 100)
+			c.mustEmitCreditMetrics(ch, response, tt.epoch)
+			close(ch)
+
+			values := make(map[*prometheus.Desc]float64)
+			for m := range ch {
+				var pb dto.Metric
+				if err := m.Write(&pb); err != nil {
+					t.Fatal(err)
+				}
+				values[m.Desc()] = pb.GetGauge().GetValue()
+			}
+			if got := values[c.validatorEpochCredits]; got != tt.current {
+				t.Errorf("epoch credits = %v, want %v", got, tt.current)
+			}
+			if got := values[c.validatorPreviousEpochCredits]; got != tt.previous {
+				t.Errorf("previous epoch credits = %v, want %v", got, tt.previous)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"sync"
+	"time"
+
+	"github.com/certusone/solana_exporter/pkg/rpc"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type (
+	// delinquencyTracker remembers whether each vote account was delinquent at the previous refresh, to export state
+	// transitions and how long validators were delinquent.
+	delinquencyTracker struct {
+		mu       sync.Mutex
+		epoch    int64
+		accounts map[string]*delinquencyState
+	}
+
+	delinquencyState struct {
+		delinquent bool
+		// Time and slot the current state was first observed at. The slot is unknownSlot if the node's slot was
+		// unavailable then.
+		since     time.Time
+		sinceSlot int64
+		// Number of changes between delinquent and current.
+		transitions int64
+		// Time spent delinquent in the current epoch, up to lastSeen.
+		epochDelinquent time.Duration
+		lastSeen        time.Time
+	}
+)
+
+// observe records the state of account at slot in epoch and returns a copy of its tracked state.
+func (t *delinquencyTracker) observe(account rpc.VoteAccount, delinquent bool, epoch, slot int64, now time.Time) delinquencyState {
+	s, ok := t.accounts[account.VotePubkey]
+	if !ok {
+		s = &delinquencyState{delinquent: delinquent, since: now, sinceSlot: slot, lastSeen: now}
+		t.accounts[account.VotePubkey] = s
+	}
+
+	if epoch != t.epoch {
+		s.epochDelinquent = 0
+	}
+	if s.delinquent {
+		// Attribute the time since the previous refresh to the previous state. At an epoch change, a delinquency
+		// still open carries over to the new epoch.
+		s.epochDelinquent += now.Sub(s.lastSeen)
+	}
+	s.lastSeen = now
+
+	if s.delinquent != delinquent {
+		s.delinquent = delinquent
+		s.since, s.sinceSlot = now, slot
+		s.transitions++
+	}
+	return *s
+}
+
+// mustEmitDelinquencyMetrics exports the delinquency state of every validator and the share of the activated stake
+// that is delinquent. slot and epoch are the RPC node's current slot and epoch, or unknownSlot and unknownEpoch.
+func (c *solanaCollector) mustEmitDelinquencyMetrics(ch chan<- prometheus.Metric, response *rpc.VoteAccounts, slot, epoch int64) {
+	t := &c.delinquency
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.accounts == nil {
+		t.accounts = make(map[string]*delinquencyState)
+	}
+	if epoch == unknownEpoch {
+		// Keep counting in the last known epoch.
+		epoch = t.epoch
+	}
+	now := time.Now()
+	seen := make(map[string]bool, len(response.Current)+len(response.Delinquent))
+
+	var totalStake, delinquentStake int64
+	emit := func(accounts []rpc.VoteAccount, delinquent bool) {
+		for _, account := range accounts {
+			seen[account.VotePubkey] = true
+			totalStake += account.ActivatedStake
+			if delinquent {
+				delinquentStake += account.ActivatedStake
+			}
+
+			s := t.observe(account, delinquent, epoch, slot, now)
+			var streak time.Duration
+			if s.delinquent {
+				streak = now.Sub(s.since)
+			}
+
+			ch <- prometheus.MustNewConstMetric(c.validatorDelinquencyTransitions, prometheus.CounterValue,
+				float64(s.transitions), account.VotePubkey, account.NodePubkey)
+			ch <- prometheus.MustNewConstMetric(c.validatorDelinquentSeconds, prometheus.GaugeValue,
+				streak.Seconds(), account.VotePubkey, account.NodePubkey)
+			ch <- prometheus.MustNewConstMetric(c.validatorEpochDelinquentSeconds, prometheus.GaugeValue,
+				s.epochDelinquent.Seconds(), account.VotePubkey, account.NodePubkey)
+			if s.sinceSlot != unknownSlot {
+				ch <- prometheus.MustNewConstMetric(c.validatorStateSinceSlot, prometheus.GaugeValue,
+					float64(s.sinceSlot), account.VotePubkey, account.NodePubkey)
+			}
+		}
+	}
+	emit(response.Current, false)
+	emit(response.Delinquent, true)
+
+	t.epoch = epoch
+	for votePubkey := range t.accounts {
+		if !seen[votePubkey] {
+			delete(t.accounts, votePubkey)
+		}
+	}
+
+	if totalStake > 0 {
+		ch <- prometheus.MustNewConstMetric(c.clusterDelinquentStake, prometheus.GaugeValue,
+			100*float64(delinquentStake)/float64(totalStake))
+	}
+}
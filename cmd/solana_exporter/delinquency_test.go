@@ -0,0 +1,39 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/certusone/solana_exporter/pkg/rpc"
+)
+
+func TestDelinquencyTracker(t *testing.T) {
+	tracker := delinquencyTracker{accounts: make(map[string]*delinquencyState)}
+	account := rpc.VoteAccount{VotePubkey: "a"}
+	start := time.Unix(1600000000, 0)
+
+	for _, tt := range []struct {
+		name            string
+		delinquent      bool
+		epoch           int64
+		after           time.Duration
+		transitions     int64
+		epochDelinquent time.Duration
+	}{
+		{"current", false, 100, 0, 0, 0},
+		{"becomes delinquent", true, 100, 10 * time.Second, 1, 0},
+		{"still delinquent", true, 100, 20 * time.Second, 1, 10 * time.Second},
+		// The delinquency open at the epoch change carries over.
+		{"epoch change", true, 101, 30 * time.Second, 1, 10 * time.Second},
+		{"recovers", false, 101, 40 * time.Second, 2, 20 * time.Second},
+		{"stays current", false, 101, 50 * time.Second, 2, 20 * time.Second},
+		{"next epoch", false, 102, 60 * time.Second, 2, 0},
+	} {
+		s := tracker.observe(account, tt.delinquent, tt.epoch, 1000, start.Add(tt.after))
+		tracker.epoch = tt.epoch
+		if s.transitions != tt.transitions || s.epochDelinquent != tt.epochDelinquent {
+			t.Errorf("%s: transitions = %d, epoch delinquent = %v; want %d, %v",
+				tt.name, s.transitions, s.epochDelinquent, tt.transitions, tt.epochDelinquent)
+		}
+	}
+}
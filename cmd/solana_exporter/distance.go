@@ -0,0 +1,66 @@
+package main
+
+import (
+	"github.com/certusone/solana_exporter/pkg/rpc"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Upper bounds of the vote distance histogram buckets, in slots.
+var voteDistanceBuckets = []float64{0, 1, 2, 4, 8, 16, 32, 64, 128, 256, 512, 1024}
+
+// distance returns how many slots slot is behind reference, or zero if it is not.
+func distance(reference, slot int64) int64 {
+	if slot >= reference {
+		return 0
+	}
+	return reference - slot
+}
+
+// mustEmitDistanceMetrics exports how far each validator's last vote and root are behind the most advanced validator
+// and behind nodeSlot, the RPC node's current slot, along with a histogram of the cluster's stake by vote distance. The
+// distances to the node are left out if nodeSlot is unknownSlot.
+func (c *solanaCollector) mustEmitDistanceMetrics(ch chan<- prometheus.Metric, response *rpc.VoteAccounts, nodeSlot int64) {
+	accounts := append(response.Current, response.Delinquent...)
+
+	var maxVote, maxRoot int64
+	for _, account := range accounts {
+		if account.LastVote > maxVote {
+			maxVote = account.LastVote
+		}
+		if account.RootSlot > maxRoot {
+			maxRoot = account.RootSlot
+		}
+	}
+
+	var (
+		stake   uint64
+		sum     float64
+		buckets = make(map[float64]uint64, len(voteDistanceBuckets))
+	)
+	for _, account := range accounts {
+		voteDistance := distance(maxVote, account.LastVote)
+
+		ch <- prometheus.MustNewConstMetric(c.validatorVoteDistance, prometheus.GaugeValue,
+			float64(voteDistance), account.VotePubkey, account.NodePubkey, "cluster")
+		ch <- prometheus.MustNewConstMetric(c.validatorRootDistance, prometheus.GaugeValue,
+			float64(distance(maxRoot, account.RootSlot)), account.VotePubkey, account.NodePubkey, "cluster")
+		if nodeSlot != unknownSlot {
+			ch <- prometheus.MustNewConstMetric(c.validatorVoteDistance, prometheus.GaugeValue,
+				float64(distance(nodeSlot, account.LastVote)), account.VotePubkey, account.NodePubkey, "node")
+			ch <- prometheus.MustNewConstMetric(c.validatorRootDistance, prometheus.GaugeValue,
+				float64(distance(nodeSlot, account.RootSlot)), account.VotePubkey, account.NodePubkey, "node")
+		}
+
+		// Buckets are cumulative.
+		weight := uint64(account.ActivatedStake)
+		stake += weight
+		sum += float64(weight) * float64(voteDistance)
+		for _, bound := range voteDistanceBuckets {
+			if float64(voteDistance) <= bound {
+				buckets[bound] += weight
+			}
+		}
+	}
+
+	ch <- prometheus.MustNewConstHistogram(c.clusterVoteDistance, stake, sum, buckets)
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/certusone/solana_exporter/pkg/rpc"
+	"k8s.io/klog/v2"
+)
+
+// epochLeaderStats counts the leader slots of every validator in a single epoch.
+type epochLeaderStats struct {
+	epoch    int64
+	assigned map[string]int64
+	produced map[string]int64
+	skipped  map[string]int64
+	// Whether the slots before accounting started are included. Only the assigned slots are exported otherwise.
+	complete bool
+}
+
+// newEpochLeaderStats starts counting the leader slots of epoch, whose schedule is leaders. If accounting starts
+// after the first slot of the epoch, the slots before watermark are taken from getBlockProduction, so that the
+// counts stay complete across restarts. It returns an error if getBlockProduction should be retried; if the node
+// rejects it, the stats are marked incomplete.
+func (c *solanaCollector) newEpochLeaderStats(epoch, epochFirst, watermark int64, leaders map[int64]string) (*epochLeaderStats, error) {
+	s := &epochLeaderStats{
+		epoch:    epoch,
+		assigned: make(map[string]int64),
+		produced: make(map[string]int64),
+		skipped:  make(map[string]int64),
+		complete: true,
+	}
+	for _, leader := range leaders {
+		s.assigned[leader]++
+	}
+
+	if watermark > epochFirst {
+		ctx, cancel := context.WithTimeout(context.Background(), httpTimeout)
+		production, _, err := c.rpcClient.GetBlockProduction(ctx, rpc.CommitmentMax,
+			rpc.BlockProductionFilter{FirstSlot: epochFirst, LastSlot: watermark - 1})
+		cancel()
+		var rpcErr *rpc.Error
+		if errors.As(err, &rpcErr) && !rpc.IsNodeBehind(err) {
+			klog.Warningf("node rejected block production request of epoch %d, only exporting assigned leader slots: %v", epoch, err)
+			s.complete = false
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to request block production of epoch %d: %w", epoch, err)
+		} else {
+			for leader, p := range production.ByIdentity {
+				s.produced[leader] = p.BlocksProduced
+				s.skipped[leader] = p.SkippedSlots()
+			}
+		}
+	}
+
+	// Drop the previous epoch's leaders.
+	leaderSlotsAssigned.Reset()
+	leaderSlotsProduced.Reset()
+	leaderSlotsSkipped.Reset()
+	leaderSlotsRemaining.Reset()
+	leaderSkipRate.Reset()
+
+	s.export()
+	return s, nil
+}
+
+func (s *epochLeaderStats) record(leader string, produced bool) {
+	if produced {
+		s.produced[leader]++
+	} else {
+		s.skipped[leader]++
+	}
+}
+
+// export updates the per-epoch leader gauges.
+func (s *epochLeaderStats) export() {
+	for leader, assigned := range s.assigned {
+		produced, skipped := s.produced[leader], s.skipped[leader]
+
+		leaderSlotsAssigned.WithLabelValues(leader).Set(float64(assigned))
+		if !s.complete {
+			continue
+		}
+		leaderSlotsProduced.WithLabelValues(leader).Set(float64(produced))
+		leaderSlotsSkipped.WithLabelValues(leader).Set(float64(skipped))
+		leaderSlotsRemaining.WithLabelValues(leader).Set(float64(assigned - produced - skipped))
+		if produced+skipped > 0 {
+			leaderSkipRate.WithLabelValues(leader).Set(float64(skipped) / float64(produced+skipped))
+		}
+	}
+}
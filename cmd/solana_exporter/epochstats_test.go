@@ -0,0 +1,139 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/certusone/solana_exporter/pkg/rpc"
+)
+
+func TestEpochLeaderStats(t *testing.T) {
+	// Leader "a" has slots 0 to 39 of the epoch starting at slot 1000, "b" the 60 others.
+	leaders := make(map[int64]string, 100)
+	for i := int64(0); i < 100; i++ {
+		leaders[i] = "b"
+		if i < 40 {
+			leaders[i] = "a"
+		}
+	}
+	type outcome struct {
+		leader   string
+		produced bool
+	}
+
+	for _, tt := range []struct {
+		name      string
+		watermark int64
+		// Response to getBlockProduction, unless rpcErr is set.
+		production map[string][]int64
+		rpcErr     *rpc.Error
+		// Slots accounted for after the stats were created.
+		recorded []outcome
+
+		wantErr                                bool
+		produced, skipped, remaining, skipRate map[string]float64
+	}{
+		{
+			name:      "seeded from getBlockProduction",
+			watermark: 1050,
+			production: map[string][]int64{
+				"a": {40, 30},
+				"b": {10, 10},
+			},
+			recorded:  []outcome{{"b", true}, {"b", false}},
+			produced:  map[string]float64{"a": 30, "b": 11},
+			skipped:   map[string]float64{"a": 10, "b": 1},
+			remaining: map[string]float64{"a": 0, "b": 48},
+			skipRate:  map[string]float64{"a": 0.25, "b": 1.0 / 12},
+		},
+		{
+			name:      "start of the epoch",
+			watermark: 1000,
+			recorded:  []outcome{{"a", true}, {"a", false}, {"a", false}, {"a", true}},
+			produced:  map[string]float64{"a": 2, "b": 0},
+			skipped:   map[string]float64{"a": 2, "b": 0},
+			remaining: map[string]float64{"a": 36, "b": 60},
+			// No skip rate before the first leader slot.
+			skipRate: map[string]float64{"a": 0.5},
+		},
+		{
+			name:      "rejected by the node",
+			watermark: 1050,
+			rpcErr:    &rpc.Error{Code: -32601, Message: "Method not found"},
+			recorded:  []outcome{{"b", true}},
+			// Only the assigned slots are exported.
+			produced:  map[string]float64{},
+			skipped:   map[string]float64{},
+			remaining: map[string]float64{},
+			skipRate:  map[string]float64{},
+		},
+		{
+			name:      "node behind",
+			watermark: 1050,
+			rpcErr:    &rpc.Error{Code: rpc.ErrorCodeNodeUnhealthy, Message: "Node is behind"},
+			wantErr:   true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var requested [][2]int64
+			server := newFakeRPC(t, func(method string, params []interface{}) (interface{}, *rpc.Error) {
+				if method != "getBlockProduction" {
+					return nil, &rpc.Error{Code: -32601, Message: "Method not found"}
+				}
+				r := params[0].(map[string]interface{})["range"].(map[string]interface{})
+				requested = append(requested, [2]int64{int64(r["firstSlot"].(float64)), int64(r["lastSlot"].(float64))})
+				if tt.rpcErr != nil {
+					return nil, tt.rpcErr
+				}
+				return contextValue(tt.watermark, map[string]interface{}{"byIdentity": tt.production}), nil
+			})
+			defer server.Close()
+
+			client := rpc.NewRPCClient(server.URL)
+			client.Retry.MaxAttempts = 1
+			config, _ := newConfigStore("")
+			c := NewSolanaCollector(client, config)
+
+			stats, err := c.newEpochLeaderStats(10, 1000, tt.watermark, leaders)
+			if tt.watermark > 1000 {
+				if want := [][2]int64{{1000, tt.watermark - 1}}; !reflect.DeepEqual(requested, want) {
+					t.Errorf("requested block production of %v, want %v", requested, want)
+				}
+			} else if requested != nil {
+				t.Errorf("requested block production of %v at the start of the epoch", requested)
+			}
+			if tt.wantErr {
+				if err == nil {
+					t.Error("newEpochLeaderStats succeeded")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("newEpochLeaderStats failed: %v", err)
+			}
+
+			for _, o := range tt.recorded {
+				stats.record(o.leader, o.produced)
+			}
+			stats.export()
+
+			if got, want := gaugeValues(t, leaderSlotsAssigned), map[string]float64{"a": 40, "b": 60}; !reflect.DeepEqual(got, want) {
+				t.Errorf("assigned %v, want %v", got, want)
+			}
+			for _, g := range []struct {
+				name string
+				got  map[string]float64
+				want map[string]float64
+			}{
+				{"produced", gaugeValues(t, leaderSlotsProduced), tt.produced},
+				{"skipped", gaugeValues(t, leaderSlotsSkipped), tt.skipped},
+				{"remaining", gaugeValues(t, leaderSlotsRemaining), tt.remaining},
+				{"skip rate", gaugeValues(t, leaderSkipRate), tt.skipRate},
+			} {
+				if !reflect.DeepEqual(g.got, g.want) {
+					t.Errorf("%s %v, want %v", g.name, g.got, g.want)
+				}
+			}
+		})
+	}
+}
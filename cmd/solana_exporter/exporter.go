@@ -5,8 +5,9 @@ import (
 	"flag"
 	"fmt"
 	"net/http"
-	
+
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/certusone/solana_exporter/pkg/rpc"
@@ -18,16 +19,27 @@ import (
 
 const (
 	httpTimeout = 5 * time.Second
-)
 
-type Pubkey struct {
-	value string
-}
+	healthCheckInterval = 10 * time.Second
+
+	// Slot and epoch passed to the metrics depending on them when getEpochInfo fails.
+	unknownSlot  = -1
+	unknownEpoch = -1
+)
 
 var (
-	rpcAddr = flag.String("rpcURI", "", "Solana RPC URI (including protocol and path)")
-	addr    = flag.String("addr", ":8080", "Listen address")
-	pubkeys []Pubkey
+	configPath         = flag.String("config", "", "Path to a JSON config file listing the accounts to monitor (see config.json)")
+	rpcAddr            = flag.String("rpcURI", "", "Solana RPC URI (including protocol and path). Separate multiple URIs with commas to fail over between them, in order of preference")
+	wsAddr             = flag.String("wsURI", "", "Solana PubSub WebSocket URI (ws:// or wss://). If set, slots are tracked on root notifications instead of polling every second")
+	refreshInterval    = flag.Duration("refreshInterval", 15*time.Second, "Interval at which collectors refresh the metrics served to scrapes")
+	collectorIntervals = flag.String("collectorRefreshIntervals", "", "Comma-separated collector=duration pairs overriding -refreshInterval for some collectors, such as validators=30s,supply=5m")
+	statePath          = flag.String("stateFile", "", "File to persist the leader slot watermark in, so that slots missed while the exporter was down are backfilled on startup")
+	probeInterval      = flag.Duration("probeInterval", 0, "Interval at which the RPC, gossip and TPU endpoints of the tracked validators are probed (0 disables probing)")
+	maxSlotLag         = flag.Int64("maxSlotLag", 150, "Number of slots an RPC endpoint may lag behind the most advanced one before it is considered unhealthy")
+	addr               = flag.String("addr", ":8080", "Listen address")
+
+	rpcMaxAttempts  = flag.Int("rpcMaxAttempts", rpc.DefaultRetryPolicy.MaxAttempts, "Number of attempts for RPC calls failing with HTTP 429/503 or because the node is behind (1 disables retries)")
+	rpcRetryBackoff = flag.Duration("rpcRetryBackoff", rpc.DefaultRetryPolicy.InitialBackoff, "Initial backoff between RPC retries, doubled (with jitter) after every attempt")
 )
 
 func init() {
@@ -35,13 +47,43 @@ func init() {
 }
 
 type solanaCollector struct {
-	rpcClient *rpc.RPCClient
-
-	totalValidatorsDesc     *prometheus.Desc
-	validatorActivatedStake *prometheus.Desc
-	validatorLastVote       *prometheus.Desc
-	validatorRootSlot       *prometheus.Desc
-	validatorDelinquent     *prometheus.Desc
+	rpcClient   *rpc.RPCClient
+	config      *configStore
+	leaders     *leaderTracker
+	credits     creditTracker
+	commission  commissionTracker
+	delinquency delinquencyTracker
+	blockTimes  blockTimeSampler
+
+	// Latest getVoteAccounts response, shared with the cluster_nodes collector.
+	voteAccountsMu sync.Mutex
+	voteAccounts   *rpc.VoteAccounts
+
+	totalValidatorsDesc             *prometheus.Desc
+	validatorActivatedStake         *prometheus.Desc
+	validatorLastVote               *prometheus.Desc
+	validatorRootSlot               *prometheus.Desc
+	validatorDelinquent             *prometheus.Desc
+	validatorEpochCredits           *prometheus.Desc
+	validatorPreviousEpochCredits   *prometheus.Desc
+	validatorCreditsDelta           *prometheus.Desc
+	validatorCreditsRank            *prometheus.Desc
+	clusterCreditsAverage           *prometheus.Desc
+	clusterCreditsQuantile          *prometheus.Desc
+	validatorCommission             *prometheus.Desc
+	validatorVoteDistance           *prometheus.Desc
+	validatorRootDistance           *prometheus.Desc
+	clusterVoteDistance             *prometheus.Desc
+	validatorDelinquencyTransitions *prometheus.Desc
+	validatorDelinquentSeconds      *prometheus.Desc
+	validatorEpochDelinquentSeconds *prometheus.Desc
+	validatorStateSinceSlot         *prometheus.Desc
+	clusterDelinquentStake          *prometheus.Desc
+	clusterStake                    *prometheus.Desc
+	validatorStakeShare             *prometheus.Desc
+	clusterNakamotoCoefficient      *prometheus.Desc
+	clusterSuperminorityStake       *prometheus.Desc
+	clusterStakeGini                *prometheus.Desc
 }
 
 type accountCollector struct {
@@ -62,12 +104,14 @@ type supplyCollector struct {
 }
 type balanceCollector struct {
 	rpcClient   *rpc.RPCClient
+	config      *configStore
 	contextSlot *prometheus.Desc
 	value       *prometheus.Desc
 }
 
 type stakeactivationCollector struct {
 	rpcClient *rpc.RPCClient
+	config    *configStore
 	active    *prometheus.Desc
 	inactive  *prometheus.Desc
 	state     *prometheus.Desc
@@ -75,6 +119,7 @@ type stakeactivationCollector struct {
 
 type tokenaccountbyownerCollector struct {
 	rpcClient *rpc.RPCClient
+	config    *configStore
 
 	contextSlot     *prometheus.Desc
 	program         *prometheus.Desc
@@ -95,8 +140,18 @@ type tokenaccountbyownerCollector struct {
 	rentEpoch       *prometheus.Desc
 }
 
+type tokenaccountbalanceCollector struct {
+	rpcClient   *rpc.RPCClient
+	config      *configStore
+	contextSlot *prometheus.Desc
+	amount      *prometheus.Desc
+	decimals    *prometheus.Desc
+	uiAmount    *prometheus.Desc
+}
+
 type tokensupplyCollector struct {
 	rpcClient      *rpc.RPCClient
+	config         *configStore
 	contextSlot    *prometheus.Desc
 	amount         *prometheus.Desc
 	decimals       *prometheus.Desc
@@ -106,6 +161,7 @@ type tokensupplyCollector struct {
 
 type accountinfobase64Collector struct {
 	rpcClient   *rpc.RPCClient
+	config      *configStore
 	contextSlot *prometheus.Desc
 	data        *prometheus.Desc
 	executable  *prometheus.Desc
@@ -116,6 +172,7 @@ type accountinfobase64Collector struct {
 
 type getaccountinfojsonparsedCollector struct {
 	rpcClient            *rpc.RPCClient
+	config               *configStore
 	contextSlot          *prometheus.Desc
 	authority            *prometheus.Desc
 	blockhash            *prometheus.Desc
@@ -127,9 +184,11 @@ type getaccountinfojsonparsedCollector struct {
 	//addressAcc  *prometheus.Desc
 }
 
-func NewSolanaCollector(rpcAddr string) *solanaCollector {
+func NewSolanaCollector(client *rpc.RPCClient, config *configStore) *solanaCollector {
 	return &solanaCollector{
-		rpcClient: rpc.NewRPCClient(rpcAddr),
+		rpcClient: client,
+		config:    config,
+		leaders:   newLeaderTracker(),
 		totalValidatorsDesc: prometheus.NewDesc(
 			"solana_active_validators",
 			"Total number of active validators by state",
@@ -150,12 +209,92 @@ func NewSolanaCollector(rpcAddr string) *solanaCollector {
 			"solana_validator_delinquent",
 			"Whether a validator is delinquent",
 			[]string{"pubkey", "nodekey"}, nil),
+		validatorEpochCredits: prometheus.NewDesc(
+			"solana_validator_epoch_credits",
+			"Vote credits earned by each validator in the current epoch",
+			[]string{"pubkey", "nodekey"}, nil),
+		validatorPreviousEpochCredits: prometheus.NewDesc(
+			"solana_validator_previous_epoch_credits",
+			"Vote credits earned by each validator in the previous epoch",
+			[]string{"pubkey", "nodekey"}, nil),
+		validatorCreditsDelta: prometheus.NewDesc(
+			"solana_validator_credits_since_last_refresh",
+			"Vote credits earned by each validator since the previous refresh",
+			[]string{"pubkey", "nodekey"}, nil),
+		validatorCreditsRank: prometheus.NewDesc(
+			"solana_validator_epoch_credits_rank",
+			"Rank of each tracked validator by vote credits in the current epoch, starting at 1",
+			[]string{"pubkey", "nodekey"}, nil),
+		clusterCreditsAverage: prometheus.NewDesc(
+			"solana_cluster_epoch_credits_average",
+			"Average vote credits earned by validators in the current epoch",
+			nil, nil),
+		clusterCreditsQuantile: prometheus.NewDesc(
+			"solana_cluster_epoch_credits",
+			"Quantiles of the vote credits earned by validators in the current epoch",
+			[]string{"quantile"}, nil),
+		validatorCommission: prometheus.NewDesc(
+			"solana_validator_commission",
+			"Commission of each validator in percent",
+			[]string{"votekey", "nodekey"}, nil),
+		validatorVoteDistance: prometheus.NewDesc(
+			"solana_validator_vote_distance",
+			"Number of slots each validator's last vote is behind the most advanced validator's (reference=cluster) or the RPC node's current slot (reference=node)",
+			[]string{"pubkey", "nodekey", "reference"}, nil),
+		validatorRootDistance: prometheus.NewDesc(
+			"solana_validator_root_distance",
+			"Number of slots each validator's root is behind the most advanced validator's (reference=cluster) or the RPC node's current slot (reference=node)",
+			[]string{"pubkey", "nodekey", "reference"}, nil),
+		clusterVoteDistance: prometheus.NewDesc(
+			"solana_cluster_vote_distance_stake",
+			"Activated stake in lamports by number of slots the validator's last vote is behind the most advanced validator's",
+			nil, nil),
+		validatorDelinquencyTransitions: prometheus.NewDesc(
+			"solana_validator_delinquency_transitions_total",
+			"Number of times each validator became delinquent or recovered since startup",
+			[]string{"pubkey", "nodekey"}, nil),
+		validatorDelinquentSeconds: prometheus.NewDesc(
+			"solana_validator_delinquent_seconds",
+			"Duration of the current delinquency of each validator, 0 if it is not delinquent",
+			[]string{"pubkey", "nodekey"}, nil),
+		validatorEpochDelinquentSeconds: prometheus.NewDesc(
+			"solana_validator_epoch_delinquent_seconds",
+			"Time each validator was observed delinquent in the current epoch",
+			[]string{"pubkey", "nodekey"}, nil),
+		validatorStateSinceSlot: prometheus.NewDesc(
+			"solana_validator_delinquency_state_since_slot",
+			"Slot at which the current delinquency state of each validator was first observed",
+			[]string{"pubkey", "nodekey"}, nil),
+		clusterDelinquentStake: prometheus.NewDesc(
+			"solana_cluster_delinquent_stake_percent",
+			"Percentage of the activated stake held by delinquent validators",
+			nil, nil),
+		clusterStake: prometheus.NewDesc(
+			"solana_cluster_stake",
+			"Activated stake in lamports of current (state=active) and delinquent (state=delinquent) validators",
+			[]string{"state"}, nil),
+		validatorStakeShare: prometheus.NewDesc(
+			"solana_validator_stake_share_percent",
+			"Percentage of the total activated stake held by each validator",
+			[]string{"pubkey", "nodekey"}, nil),
+		clusterNakamotoCoefficient: prometheus.NewDesc(
+			"solana_cluster_nakamoto_coefficient",
+			"Minimum number of validators holding more than a third of the activated stake (the superminority)",
+			nil, nil),
+		clusterSuperminorityStake: prometheus.NewDesc(
+			"solana_cluster_superminority_stake",
+			"Activated stake in lamports held by the superminority",
+			nil, nil),
+		clusterStakeGini: prometheus.NewDesc(
+			"solana_cluster_stake_gini_coefficient",
+			"Gini coefficient of the activated stake of all validators",
+			nil, nil),
 	}
 }
 
-func NewAccCollector(rpcAddr string) *accountCollector {
+func NewAccCollector(client *rpc.RPCClient) *accountCollector {
 	return &accountCollector{
-		rpcClient: rpc.NewRPCClient(rpcAddr),
+		rpcClient: client,
 		contextSlot: prometheus.NewDesc(
 			"solana_acc_context_slot",
 			"Total number of Solana Context Slot",
@@ -178,9 +317,9 @@ func NewAccCollector(rpcAddr string) *accountCollector {
 	}
 }
 
-func NewSupplyCollector(rpcAddr string) *supplyCollector {
+func NewSupplyCollector(client *rpc.RPCClient) *supplyCollector {
 	return &supplyCollector{
-		rpcClient: rpc.NewRPCClient(rpcAddr),
+		rpcClient: client,
 		contextSlot: prometheus.NewDesc(
 			"solana_context_slot",
 			"Current slot in context",
@@ -204,9 +343,10 @@ func NewSupplyCollector(rpcAddr string) *supplyCollector {
 	}
 }
 
-func NewBalanceCollector(rpcAddr string) *balanceCollector {
+func NewBalanceCollector(client *rpc.RPCClient, config *configStore) *balanceCollector {
 	return &balanceCollector{
-		rpcClient: rpc.NewRPCClient(rpcAddr),
+		rpcClient: client,
+		config:    config,
 
 		contextSlot: prometheus.NewDesc(
 			"balancecollector_context_slot",
@@ -219,105 +359,131 @@ func NewBalanceCollector(rpcAddr string) *balanceCollector {
 	}
 }
 
-func NewTokenAccByOwnerCollector(rpcAddr string) *tokenaccountbyownerCollector {
+func NewTokenAccByOwnerCollector(client *rpc.RPCClient, config *configStore) *tokenaccountbyownerCollector {
 	return &tokenaccountbyownerCollector{
-		rpcClient: rpc.NewRPCClient(rpcAddr),
+		rpcClient: client,
+		config:    config,
 
 		contextSlot: prometheus.NewDesc(
 			"Context_Slot_Acc_Owner",
 			"Context Slot",
-			[]string{"pubkey"}, nil),
+			[]string{"pubkey", "token_account", "mint"}, nil),
 
 		program: prometheus.NewDesc(
 			"Program_Type_Token_Owner",
 			"Program Type",
-			[]string{"pubkey", "program"}, nil),
+			[]string{"pubkey", "token_account", "mint", "program"}, nil),
 
 		accountType: prometheus.NewDesc(
 			"Acc_Type_Token_Owner",
 			"Account Type",
-			[]string{"pubkey", "accountType"}, nil),
+			[]string{"pubkey", "token_account", "mint", "accountType"}, nil),
 
 		amount: prometheus.NewDesc(
 			"Token_Amount",
 			"Token amount",
-			[]string{"pubkey"}, nil),
+			[]string{"pubkey", "token_account", "mint"}, nil),
 
 		decimals: prometheus.NewDesc(
 			"Decimals_Token_Amount",
 			"Decimal for Token Amount",
-			[]string{"pubkey"}, nil),
+			[]string{"pubkey", "token_account", "mint"}, nil),
 
 		uiAmount: prometheus.NewDesc(
 			"Token_UiAmount",
 			"UiAmount for Token Amount",
-			[]string{"pubkey"}, nil),
+			[]string{"pubkey", "token_account", "mint"}, nil),
 
 		uiAmountString: prometheus.NewDesc(
 			"Token_UiAmountString",
 			"UiAmountString for Token Amount",
-			[]string{"pubkey"}, nil),
+			[]string{"pubkey", "token_account", "mint"}, nil),
 
 		delegate: prometheus.NewDesc(
 			"Delegate_Info_Acc_Owner",
 			"Delegate info for acc owner",
-			[]string{"pubkey", "delegate"}, nil),
+			[]string{"pubkey", "token_account", "mint", "delegate"}, nil),
 
 		delegatedAmount: prometheus.NewDesc(
 			"Delegated_Amount_Acc_Owner",
 			"Delegated Amount for Acc",
-			[]string{"pubkey"}, nil),
+			[]string{"pubkey", "token_account", "mint"}, nil),
 
 		isInitialized: prometheus.NewDesc(
 			"Acc_Is_Initialized",
 			"Is Token Account initialized",
-			[]string{"pubkey", "isInitialized"}, nil),
+			[]string{"pubkey", "token_account", "mint", "isInitialized"}, nil),
 
 		isNative: prometheus.NewDesc(
 			"Acc_Is_Native",
 			"Is Token Account native",
-			[]string{"pubkey", "isNative"}, nil),
+			[]string{"pubkey", "token_account", "mint", "isNative"}, nil),
 
 		mint: prometheus.NewDesc(
 			"Token_Account_Mint",
 			"Mint token for Account",
-			[]string{"pubkey", "mint"}, nil),
+			[]string{"pubkey", "token_account", "mint"}, nil),
 
 		ownerInfo: prometheus.NewDesc(
 			"Token_Account_Owner_Info",
 			"Owner Info for Token Account",
-			[]string{"pubkey", "ownerInfo"}, nil),
+			[]string{"pubkey", "token_account", "mint", "ownerInfo"}, nil),
 
 		executable: prometheus.NewDesc(
 			"Executable_Token_Account",
 			"Token Account is Executable",
-			[]string{"pubkey", "executbale"}, nil),
+			[]string{"pubkey", "token_account", "mint", "executbale"}, nil),
 
 		lamports: prometheus.NewDesc(
 			"Token_Account_Lamports",
 			"Lamports for token account",
-			[]string{"pubkey"}, nil),
+			[]string{"pubkey", "token_account", "mint"}, nil),
 
 		owner: prometheus.NewDesc(
 			"Owner_Token_Account",
 			"Owner of Token Account",
-			[]string{"pubkey", "owner"}, nil),
+			[]string{"pubkey", "token_account", "mint", "owner"}, nil),
 
 		rentEpoch: prometheus.NewDesc(
 			"Token_Account_Rent_Epoch",
 			"Rent epoch for Token Account",
+			[]string{"pubkey", "token_account", "mint"}, nil),
+	}
+}
+
+func NewTokenAccountBalanceCollector(client *rpc.RPCClient, config *configStore) *tokenaccountbalanceCollector {
+	return &tokenaccountbalanceCollector{
+		rpcClient: client,
+		config:    config,
+
+		contextSlot: prometheus.NewDesc(
+			"solana_token_account_context_slot",
+			"Context slot of the token account balance",
+			[]string{"pubkey"}, nil),
+		amount: prometheus.NewDesc(
+			"solana_token_account_amount",
+			"Raw token account balance, without decimals",
+			[]string{"pubkey"}, nil),
+		decimals: prometheus.NewDesc(
+			"solana_token_account_decimals",
+			"Number of decimals of the token account's mint",
+			[]string{"pubkey"}, nil),
+		uiAmount: prometheus.NewDesc(
+			"solana_token_account_ui_amount",
+			"Token account balance, using the mint's decimals",
 			[]string{"pubkey"}, nil),
 	}
 }
 
-func NewTokenSuppyCollector(rpcAddr string) *tokensupplyCollector {
+func NewTokenSuppyCollector(client *rpc.RPCClient, config *configStore) *tokensupplyCollector {
 	return &tokensupplyCollector{
-		rpcClient: rpc.NewRPCClient(rpcAddr),
+		rpcClient: client,
+		config:    config,
 
 		contextSlot: prometheus.NewDesc(
 			"Token_Supply_context_slot",
 			"Total Supply For Context Slot",
-			nil, nil),
+			[]string{"pubkey"}, nil),
 
 		amount: prometheus.NewDesc(
 			"Token_Supply_Amount",
@@ -341,10 +507,11 @@ func NewTokenSuppyCollector(rpcAddr string) *tokensupplyCollector {
 	}
 }
 
-func NewStakeActivationCollector(rpcAddr string) *stakeactivationCollector {
+func NewStakeActivationCollector(client *rpc.RPCClient, config *configStore) *stakeactivationCollector {
 	return &stakeactivationCollector{
 
-		rpcClient: rpc.NewRPCClient(rpcAddr),
+		rpcClient: client,
+		config:    config,
 
 		active: prometheus.NewDesc(
 			"Active_Stake",
@@ -361,9 +528,10 @@ func NewStakeActivationCollector(rpcAddr string) *stakeactivationCollector {
 	}
 }
 
-func NewAccountInfoCollector(rpcAddr string) *accountinfobase64Collector {
+func NewAccountInfoCollector(client *rpc.RPCClient, config *configStore) *accountinfobase64Collector {
 	return &accountinfobase64Collector{
-		rpcClient: rpc.NewRPCClient(rpcAddr),
+		rpcClient: client,
+		config:    config,
 		contextSlot: prometheus.NewDesc(
 			"solana_account_information_context_slot",
 			"account Context Slot",
@@ -396,9 +564,10 @@ func NewAccountInfoCollector(rpcAddr string) *accountinfobase64Collector {
 	}
 }
 
-func NewAccountInfoJsonParsedCollector(rpcAddr string) *getaccountinfojsonparsedCollector {
+func NewAccountInfoJsonParsedCollector(client *rpc.RPCClient, config *configStore) *getaccountinfojsonparsedCollector {
 	return &getaccountinfojsonparsedCollector{
-		rpcClient: rpc.NewRPCClient(rpcAddr),
+		rpcClient: client,
+		config:    config,
 
 		contextSlot: prometheus.NewDesc(
 			"Account_Info_Json_Parsed_Context",
@@ -475,6 +644,10 @@ func (c *tokenaccountbyownerCollector) Describe(ch chan<- *prometheus.Desc) {
 	//ch <- c.contextSlot
 }
 
+func (c *tokenaccountbalanceCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.amount
+}
+
 func (c *tokensupplyCollector) Describe(ch chan<- *prometheus.Desc) {
 	//ch <- c.contextSlot
 }
@@ -485,182 +658,198 @@ func (c *getaccountinfojsonparsedCollector) Describe(ch chan<- *prometheus.Desc)
 	//ch <- c.contextSlot
 }
 
-func (c *solanaCollector) mustEmitMetrics(ch chan<- prometheus.Metric, response *rpc.GetVoteAccountsResponse) {
+func (c *solanaCollector) mustEmitMetrics(ch chan<- prometheus.Metric, response *rpc.VoteAccounts) {
 	ch <- prometheus.MustNewConstMetric(c.totalValidatorsDesc, prometheus.GaugeValue,
-		float64(len(response.Result.Delinquent)), "delinquent")
+		float64(len(response.Delinquent)), "delinquent")
 	ch <- prometheus.MustNewConstMetric(c.totalValidatorsDesc, prometheus.GaugeValue,
-		float64(len(response.Result.Current)), "current")
+		float64(len(response.Current)), "current")
 
-	for _, account := range append(response.Result.Current, response.Result.Delinquent...) {
+	for _, account := range append(response.Current, response.Delinquent...) {
 		ch <- prometheus.MustNewConstMetric(c.validatorActivatedStake, prometheus.GaugeValue,
 			float64(account.ActivatedStake), account.VotePubkey, account.NodePubkey)
 		ch <- prometheus.MustNewConstMetric(c.validatorLastVote, prometheus.GaugeValue,
 			float64(account.LastVote), account.VotePubkey, account.NodePubkey)
 		ch <- prometheus.MustNewConstMetric(c.validatorRootSlot, prometheus.GaugeValue,
 			float64(account.RootSlot), account.VotePubkey, account.NodePubkey)
-		pubkeys = append(pubkeys, Pubkey{value: account.VotePubkey})
 	}
-	fmt.Println(pubkeys)
-	for _, account := range response.Result.Current {
+	for _, account := range response.Current {
 		ch <- prometheus.MustNewConstMetric(c.validatorDelinquent, prometheus.GaugeValue,
 			0, account.VotePubkey, account.NodePubkey)
 	}
-	for _, account := range response.Result.Delinquent {
+	for _, account := range response.Delinquent {
 		ch <- prometheus.MustNewConstMetric(c.validatorDelinquent, prometheus.GaugeValue,
 			1, account.VotePubkey, account.NodePubkey)
 	}
 }
 
-func (c *supplyCollector) mustSupplyMetrics(ch chan<- prometheus.Metric, response *rpc.GetSupplyResponse) {
+func (c *supplyCollector) mustSupplyMetrics(ch chan<- prometheus.Metric, supply *rpc.Supply, rctx rpc.Context) {
 
 	ch <- prometheus.MustNewConstMetric(c.contextSlot, prometheus.GaugeValue,
-		float64(response.Result.ContextSlot))
+		float64(rctx.Slot))
 	ch <- prometheus.MustNewConstMetric(c.totalSupply, prometheus.GaugeValue,
-		float64(response.Result.Value.TotalSupply))
+		float64(supply.Total))
 	ch <- prometheus.MustNewConstMetric(c.circulatingSupply, prometheus.GaugeValue,
-		float64(response.Result.Value.CirculatingSupply))
+		float64(supply.Circulating))
 	ch <- prometheus.MustNewConstMetric(c.nonCirculatingSupply, prometheus.GaugeValue,
-		float64(response.Result.Value.NonCirculatingSupply))
+		float64(supply.NonCirculating))
 
-	for _, account := range response.Result.Value.NonCirculatingAccounts {
+	for _, account := range supply.NonCirculatingAccounts {
 		ch <- prometheus.MustNewConstMetric(c.nonCirculatingAccounts, prometheus.GaugeValue,
 			0, account)
 	}
 	// ch <- prometheus.MustNewConstMetric(c.nonCirculatingAccounts, prometheus.GaugeValue,
-	// 	0, response.Result.Value.NonCirculatingAccounts...)
+	// 	0, info.NonCirculatingAccounts...)
 }
 
-func (c *accountCollector) mustAccountMetrics(ch chan<- prometheus.Metric, response *rpc.GetLargestAccountsResponse) {
+func (c *accountCollector) mustAccountMetrics(ch chan<- prometheus.Metric, accounts []rpc.LargestAccount, rctx rpc.Context) {
 
 	ch <- prometheus.MustNewConstMetric(c.contextSlot, prometheus.GaugeValue,
-		float64(response.Result.ContextSlot))
+		float64(rctx.Slot))
 
-	for _, account := range response.Result.Value {
+	for _, account := range accounts {
 		ch <- prometheus.MustNewConstMetric(c.value, prometheus.GaugeValue,
 			float64(account.Lamports), account.Address)
 	}
 
-	// for _, account := range response.Result.Value {
+	// for _, account := range accounts {
 	// 	ch <- prometheus.MustNewConstMetric(c.addressAcc, prometheus.GaugeValue,
 	// 		0, account.Address)
 	// }
 }
 
-func (c *balanceCollector) mustBalanceMetrics(ch chan<- prometheus.Metric, response *rpc.GetBalanceResponse, pubkey string) {
+func (c *balanceCollector) mustBalanceMetrics(ch chan<- prometheus.Metric, balance int64, rctx rpc.Context, pubkey string) {
 
 	ch <- prometheus.MustNewConstMetric(c.contextSlot, prometheus.GaugeValue,
-		float64(response.Result.ContextSlot.Slot), pubkey)
+		float64(rctx.Slot), pubkey)
 	ch <- prometheus.MustNewConstMetric(c.value, prometheus.GaugeValue,
-		float64(response.Result.Value), pubkey)
+		float64(balance), pubkey)
 
 }
 
-func (c *tokensupplyCollector) mustTokenSupplyMetrics(ch chan<- prometheus.Metric, response *rpc.GetTokenSupplyResponse, pubkey string) {
+func (c *tokenaccountbalanceCollector) mustTokenAccountBalanceMetrics(ch chan<- prometheus.Metric, balance *rpc.TokenAmount, rctx rpc.Context, pubkey string) {
+	amount, _ := strconv.ParseFloat(balance.Amount, 64)
+	uiAmount, err := strconv.ParseFloat(balance.UiAmountString, 64)
+	if err != nil {
+		uiAmount = balance.UiAmount
+	}
 
 	ch <- prometheus.MustNewConstMetric(c.contextSlot, prometheus.GaugeValue,
-		float64(response.Result.ContextSlot))
+		float64(rctx.Slot), pubkey)
+	ch <- prometheus.MustNewConstMetric(c.amount, prometheus.GaugeValue,
+		amount, pubkey)
+	ch <- prometheus.MustNewConstMetric(c.decimals, prometheus.GaugeValue,
+		float64(balance.Decimals), pubkey)
+	ch <- prometheus.MustNewConstMetric(c.uiAmount, prometheus.GaugeValue,
+		uiAmount, pubkey)
+}
 
-	for _, account := range response.Result.Value {
-		ch <- prometheus.MustNewConstMetric(c.contextSlot, prometheus.GaugeValue,
-			0, account.Amount)
-		ch <- prometheus.MustNewConstMetric(c.amount, prometheus.GaugeValue,
-			0, account.Amount)
-		ch <- prometheus.MustNewConstMetric(c.decimals, prometheus.GaugeValue,
-			float64(account.Decimals))
-		ch <- prometheus.MustNewConstMetric(c.uiAmount, prometheus.GaugeValue,
-			float64(account.UiAmount))
-		ch <- prometheus.MustNewConstMetric(c.uiAmountString, prometheus.GaugeValue,
-			0, account.UiAmountString)
-	}
+func (c *tokensupplyCollector) mustTokenSupplyMetrics(ch chan<- prometheus.Metric, supply *rpc.TokenAmount, rctx rpc.Context, pubkey string) {
+
+	ch <- prometheus.MustNewConstMetric(c.contextSlot, prometheus.GaugeValue,
+		float64(rctx.Slot), pubkey)
+	ch <- prometheus.MustNewConstMetric(c.amount, prometheus.GaugeValue,
+		0, pubkey, supply.Amount)
+	ch <- prometheus.MustNewConstMetric(c.decimals, prometheus.GaugeValue,
+		float64(supply.Decimals), pubkey)
+	ch <- prometheus.MustNewConstMetric(c.uiAmount, prometheus.GaugeValue,
+		supply.UiAmount, pubkey)
+	ch <- prometheus.MustNewConstMetric(c.uiAmountString, prometheus.GaugeValue,
+		0, pubkey, supply.UiAmountString)
 }
 
-func (c *tokenaccountbyownerCollector) mustTokenAccByOwnerMetrics(ch chan<- prometheus.Metric, response *rpc.GetTokenAccountsbyownerRes, pubkey string) {
-	for _, account := range response.Result.Value {
+func (c *tokenaccountbyownerCollector) mustTokenAccByOwnerMetrics(ch chan<- prometheus.Metric, accounts []rpc.TokenAccount, rctx rpc.Context, owner PubkeyMint) {
+	for _, tokenAccount := range accounts {
+		account := tokenAccount.Account
 		amount, _ := strconv.ParseFloat(account.Data.Parsed.Info.TokenAmount.Amount, 64)
 		uiAmountString, _ := strconv.ParseFloat(account.Data.Parsed.Info.TokenAmount.UiAmountString, 64)
-		isInitialized := strconv.FormatBool(account.Data.Parsed.Info.IsInitialized)
+		delegatedAmount, _ := strconv.ParseFloat(account.Data.Parsed.Info.DelegatedAmount.Amount, 64)
+		isInitialized := strconv.FormatBool(account.Data.Parsed.Info.State == "initialized")
 		isNative := strconv.FormatBool(account.Data.Parsed.Info.IsNative)
 		executable := strconv.FormatBool(account.Executable)
+		// Owners may hold several accounts of a mint, and be listed with several mints.
+		labels := func(values ...string) []string {
+			return append([]string{owner.Pubkey, tokenAccount.Pubkey, owner.Mint}, values...)
+		}
 		ch <- prometheus.MustNewConstMetric(c.contextSlot, prometheus.GaugeValue,
-			float64(response.Result.Context.Slot), pubkey)
+			float64(rctx.Slot), labels()...)
 		ch <- prometheus.MustNewConstMetric(c.program, prometheus.GaugeValue,
-			0, pubkey, account.Data.Program)
+			0, labels(account.Data.Program)...)
 		ch <- prometheus.MustNewConstMetric(c.accountType, prometheus.GaugeValue,
-			0, pubkey, account.Data.Parsed.AccountType)
+			0, labels(account.Data.Parsed.Type)...)
 		ch <- prometheus.MustNewConstMetric(c.amount, prometheus.GaugeValue,
-			amount, pubkey)
+			amount, labels()...)
 		ch <- prometheus.MustNewConstMetric(c.decimals, prometheus.GaugeValue,
-			float64(account.Data.Parsed.Info.TokenAmount.Decimals), pubkey)
+			float64(account.Data.Parsed.Info.TokenAmount.Decimals), labels()...)
 		ch <- prometheus.MustNewConstMetric(c.uiAmount, prometheus.GaugeValue,
-			float64(account.Data.Parsed.Info.TokenAmount.UiAmount), pubkey)
+			float64(account.Data.Parsed.Info.TokenAmount.UiAmount), labels()...)
 		ch <- prometheus.MustNewConstMetric(c.uiAmountString, prometheus.GaugeValue,
-			uiAmountString, pubkey)
+			uiAmountString, labels()...)
 		ch <- prometheus.MustNewConstMetric(c.delegate, prometheus.GaugeValue,
-			0, pubkey, account.Data.Parsed.Info.Delegate)
+			0, labels(account.Data.Parsed.Info.Delegate)...)
 		ch <- prometheus.MustNewConstMetric(c.delegatedAmount, prometheus.GaugeValue,
-			float64(account.Data.Parsed.Info.DelegatedAmount), pubkey)
+			delegatedAmount, labels()...)
 		ch <- prometheus.MustNewConstMetric(c.isInitialized, prometheus.GaugeValue,
-			0, pubkey, isInitialized)
+			0, labels(isInitialized)...)
 		ch <- prometheus.MustNewConstMetric(c.isNative, prometheus.GaugeValue,
-			0, pubkey, isNative)
+			0, labels(isNative)...)
 		ch <- prometheus.MustNewConstMetric(c.mint, prometheus.GaugeValue,
-			0, pubkey, account.Data.Parsed.Info.Mint)
+			0, owner.Pubkey, tokenAccount.Pubkey, account.Data.Parsed.Info.Mint)
 		ch <- prometheus.MustNewConstMetric(c.ownerInfo, prometheus.GaugeValue,
-			0, pubkey, account.Data.Parsed.Info.Owner)
+			0, labels(account.Data.Parsed.Info.Owner)...)
 		ch <- prometheus.MustNewConstMetric(c.executable, prometheus.GaugeValue,
-			0, pubkey, executable)
+			0, labels(executable)...)
 		ch <- prometheus.MustNewConstMetric(c.lamports, prometheus.GaugeValue,
-			float64(account.Lamports), pubkey)
+			float64(account.Lamports), labels()...)
 		ch <- prometheus.MustNewConstMetric(c.owner, prometheus.GaugeValue,
-			0, pubkey, account.Owner)
+			0, labels(account.Owner)...)
 		ch <- prometheus.MustNewConstMetric(c.rentEpoch, prometheus.GaugeValue,
-			float64(account.RentEpoch), pubkey)
+			float64(account.RentEpoch), labels()...)
 	}
 }
 
-func (c *accountinfobase64Collector) mustAccountInfo64Metric(ch chan<- prometheus.Metric, response *rpc.GetAccountInfoBase64Res, pubkey string) {
+func (c *accountinfobase64Collector) mustAccountInfo64Metric(ch chan<- prometheus.Metric, info *rpc.AccountInfo, rctx rpc.Context, pubkey string) {
 
 	ch <- prometheus.MustNewConstMetric(c.contextSlot, prometheus.GaugeValue,
-		float64(response.Result.ContextSlot.Slot), pubkey)
+		float64(rctx.Slot), pubkey)
 
 	ch <- prometheus.MustNewConstMetric(c.executable, prometheus.GaugeValue,
-		0, pubkey, strconv.FormatBool(response.Result.Value.Executable))
+		0, pubkey, strconv.FormatBool(info.Executable))
 
 	ch <- prometheus.MustNewConstMetric(c.lamports, prometheus.GaugeValue,
-		float64(response.Result.Value.Lamports), pubkey)
+		float64(info.Lamports), pubkey)
 
 	ch <- prometheus.MustNewConstMetric(c.owner, prometheus.GaugeValue,
-		0, pubkey, response.Result.Value.Owner)
+		0, pubkey, info.Owner)
 
 	ch <- prometheus.MustNewConstMetric(c.rentEpoch, prometheus.GaugeValue,
-		float64(response.Result.Value.RentEpoch), pubkey)
+		float64(info.RentEpoch), pubkey)
 
-	for _, account := range response.Result.Value.Data {
+	for _, account := range info.Data.Encoded {
 		ch <- prometheus.MustNewConstMetric(c.data, prometheus.GaugeValue,
 			0, pubkey, account)
 	}
 	// ch <- prometheus.MustNewConstMetric(c.nonCirculatingAccounts, prometheus.GaugeValue,
-	// 	0, response.Result.Value.NonCirculatingAccounts...)
+	// 	0, info.NonCirculatingAccounts...)
 }
 
-func (c *getaccountinfojsonparsedCollector) mustAccountInfoJsonParsedCollector(ch chan<- prometheus.Metric, response *rpc.GetAccountInfoJsonParsedRes, pubkey string) {
+func (c *getaccountinfojsonparsedCollector) mustAccountInfoJsonParsedCollector(ch chan<- prometheus.Metric, info *rpc.AccountInfo, rctx rpc.Context, pubkey string) {
 
 	ch <- prometheus.MustNewConstMetric(c.contextSlot, prometheus.GaugeValue,
-		float64(response.Result.ContextSlot.Slot), pubkey)
+		float64(rctx.Slot), pubkey)
 	ch <- prometheus.MustNewConstMetric(c.blockhash, prometheus.GaugeValue,
-		0, pubkey, response.Result.Value.Data.Nonce.Initialized.Blockhash)
+		0, pubkey, info.Data.Parsed.Info.Blockhash)
 	ch <- prometheus.MustNewConstMetric(c.authority, prometheus.GaugeValue,
-		0, pubkey, response.Result.Value.Data.Nonce.Initialized.Authority)
+		0, pubkey, info.Data.Parsed.Info.Authority)
 	ch <- prometheus.MustNewConstMetric(c.lamportsPerSignature, prometheus.GaugeValue,
-		float64(response.Result.Value.Data.Nonce.Initialized.FeeCalculator.LamportsPerSignature), pubkey)
+		float64(info.Data.Parsed.Info.FeeCalculator.LamportsPerSignature), pubkey)
 	ch <- prometheus.MustNewConstMetric(c.executable, prometheus.GaugeValue,
-		0, pubkey, strconv.FormatBool(response.Result.Value.Executable))
+		0, pubkey, strconv.FormatBool(info.Executable))
 	ch <- prometheus.MustNewConstMetric(c.lamports, prometheus.GaugeValue,
-		float64(response.Result.Value.Lamports), pubkey)
+		float64(info.Lamports), pubkey)
 	ch <- prometheus.MustNewConstMetric(c.owner, prometheus.GaugeValue,
-		0, response.Result.Value.Owner, pubkey)
+		0, pubkey, info.Owner)
 	ch <- prometheus.MustNewConstMetric(c.rentEpoch, prometheus.GaugeValue,
-		float64(response.Result.Value.RentEpoch), pubkey)
+		float64(info.RentEpoch), pubkey)
 }
 
 func (c *solanaCollector) Collect(ch chan<- prometheus.Metric) {
@@ -675,9 +864,39 @@ func (c *solanaCollector) Collect(ch chan<- prometheus.Metric) {
 		ch <- prometheus.NewInvalidMetric(c.validatorLastVote, err)
 		ch <- prometheus.NewInvalidMetric(c.validatorRootSlot, err)
 		ch <- prometheus.NewInvalidMetric(c.validatorDelinquent, err)
+		ch <- prometheus.NewInvalidMetric(c.validatorEpochCredits, err)
+		ch <- prometheus.NewInvalidMetric(c.clusterCreditsAverage, err)
+		ch <- prometheus.NewInvalidMetric(c.validatorCommission, err)
+		ch <- prometheus.NewInvalidMetric(c.validatorVoteDistance, err)
+		ch <- prometheus.NewInvalidMetric(c.validatorDelinquencyTransitions, err)
+		ch <- prometheus.NewInvalidMetric(c.clusterNakamotoCoefficient, err)
+		return
+	}
+	c.voteAccountsMu.Lock()
+	c.voteAccounts = accs
+	c.voteAccountsMu.Unlock()
+
+	// Only the metrics relative to the node's slot or the current epoch depend on it.
+	slot, epoch := int64(unknownSlot), int64(unknownEpoch)
+	if info, err := c.rpcClient.GetEpochInfo(ctx, rpc.CommitmentRecent); err != nil {
+		ch <- prometheus.NewInvalidMetric(c.validatorVoteDistance, err)
 	} else {
-		c.mustEmitMetrics(ch, accs)
+		slot, epoch = info.AbsoluteSlot, info.Epoch
 	}
+
+	c.mustEmitMetrics(ch, accs)
+	c.mustEmitCreditMetrics(ch, accs, epoch)
+	c.mustEmitCommissionMetrics(ch, accs, epoch)
+	c.mustEmitDistanceMetrics(ch, accs, slot)
+	c.mustEmitDelinquencyMetrics(ch, accs, slot, epoch)
+	c.mustEmitConcentrationMetrics(ch, accs)
+}
+
+// latestVoteAccounts returns the vote accounts fetched by the last successful refresh, or nil before the first one.
+func (c *solanaCollector) latestVoteAccounts() *rpc.VoteAccounts {
+	c.voteAccountsMu.Lock()
+	defer c.voteAccountsMu.Unlock()
+	return c.voteAccounts
 }
 
 func (c *supplyCollector) Collect(ch chan<- prometheus.Metric) {
@@ -685,8 +904,8 @@ func (c *supplyCollector) Collect(ch chan<- prometheus.Metric) {
 	ctx, cancel := context.WithTimeout(context.Background(), httpTimeout)
 	defer cancel()
 
-	resp, err := c.rpcClient.GetSupply(ctx)
-	klog.Infof("Get Supply value is: %v", resp)
+	resp, rctx, err := c.rpcClient.GetSupply(ctx, rpc.CommitmentRecent)
+	klog.V(2).Infof("Get Supply value is: %v", resp)
 	if err != nil {
 		ch <- prometheus.NewInvalidMetric(c.contextSlot, err)
 		ch <- prometheus.NewInvalidMetric(c.totalSupply, err)
@@ -694,7 +913,7 @@ func (c *supplyCollector) Collect(ch chan<- prometheus.Metric) {
 		ch <- prometheus.NewInvalidMetric(c.nonCirculatingSupply, err)
 		ch <- prometheus.NewInvalidMetric(c.nonCirculatingAccounts, err)
 	} else {
-		c.mustSupplyMetrics(ch, resp)
+		c.mustSupplyMetrics(ch, resp, rctx)
 	}
 }
 
@@ -703,102 +922,114 @@ func (c *accountCollector) Collect(ch chan<- prometheus.Metric) {
 	ctx, cancel := context.WithTimeout(context.Background(), httpTimeout)
 	defer cancel()
 
-	accountCollector, err := c.rpcClient.GetLargestAcc(ctx)
-	klog.Infof("Get account is: %v", accountCollector)
+	accountCollector, rctx, err := c.rpcClient.GetLargestAccounts(ctx, rpc.CommitmentRecent, "")
+	klog.V(2).Infof("Get account is: %v", accountCollector)
 	if err != nil {
 		ch <- prometheus.NewInvalidMetric(c.contextSlot, err)
 		ch <- prometheus.NewInvalidMetric(c.value, err)
 		//ch <- prometheus.NewInvalidMetric(c.lamportsAcc, err)
 		//ch <- prometheus.NewInvalidMetric(c.addressAcc, err)
 	} else {
-		c.mustAccountMetrics(ch, accountCollector)
+		c.mustAccountMetrics(ch, accountCollector, rctx)
 	}
 }
 
 func (c *balanceCollector) Collect(ch chan<- prometheus.Metric) {
 
-	// var myarr [4]string
-	// myarr[0] = "83astBRguLMdt2h5U1Tpdq5tjFoJ6noeGwaY3mDLVcri"
-	// myarr[1] = "vines1vzrYbzLMRdu58ou5XTby4qAqVRLmqo36NKPTg"
-	// myarr[2] = "4fYNw3dojWmQ4dXtSGE9epjRGy9pFSx62YypT7avPYvA"
-	// myarr[3] = "6H94zdiaYfRfPfKjYLjyr2VFBg6JHXygy84r3qhc3NsC"
-
 	var ctx, cancel = context.WithTimeout(context.Background(), httpTimeout)
 	defer cancel()
 
-	response, _ := c.rpcClient.GetVoteAccounts(ctx, rpc.CommitmentRecent)
+	pubkeys := c.config.Load().AccountBalances
+	if len(pubkeys) == 0 {
+		response, err := c.rpcClient.GetVoteAccounts(ctx, rpc.CommitmentRecent)
+		if err != nil {
+			ch <- prometheus.NewInvalidMetric(c.contextSlot, err)
+			ch <- prometheus.NewInvalidMetric(c.value, err)
+			return
+		}
 
-	for index, account := range append(response.Result.Current, response.Result.Delinquent...) {
-		// pubkeys = append(pubkeys, Pubkey{value: account.VotePubkey})
-		// }
-		// fmt.Println(pubkeys)
-		// fmt.Println(len(pubkeys))
+		accounts := append(response.Current, response.Delinquent...)
+		if len(accounts) > 100 {
+			accounts = accounts[:100]
+		}
+		for _, account := range accounts {
+			pubkeys = append(pubkeys, account.VotePubkey)
+		}
+	}
 
-		//Get Balance Response
+	// Fetch all balances in a single round trip.
+	balances := make([]int64, len(pubkeys))
+	calls := make([]*rpc.BatchCall, len(pubkeys))
+	for i, pubkey := range pubkeys {
+		calls[i] = rpc.NewGetBalanceCall(rpc.CommitmentRecent, pubkey, &balances[i])
+	}
 
-		// for i := 0; i < len(pubkeys); i++ {
-		if index >= 100 {
-			break
-		}
-		var ctx, cancel = context.WithTimeout(context.Background(), httpTimeout)
-		defer cancel()
-		balanceCollector, err := c.rpcClient.GetBalance(ctx, account.VotePubkey)
+	if err := c.rpcClient.Batch(ctx, calls); err != nil {
+		ch <- prometheus.NewInvalidMetric(c.contextSlot, err)
+		ch <- prometheus.NewInvalidMetric(c.value, err)
+		return
+	}
 
-		klog.Infof("Get Balance value is: %v", balanceCollector.Result.ContextSlot)
-		if err != nil {
-			ch <- prometheus.NewInvalidMetric(c.contextSlot, err)
-			ch <- prometheus.NewInvalidMetric(c.value, err)
+	for i, call := range calls {
+		klog.V(2).Infof("Get Balance value is: %v", balances[i])
+		if call.Err != nil {
+			ch <- prometheus.NewInvalidMetric(c.contextSlot, call.Err)
+			ch <- prometheus.NewInvalidMetric(c.value, call.Err)
 		} else {
-			c.mustBalanceMetrics(ch, balanceCollector, account.VotePubkey)
+			c.mustBalanceMetrics(ch, balances[i], call.Context(), pubkeys[i])
 		}
 	}
 }
 
-func (c *stakeactivationCollector) mustStakeActivationMetrics(ch chan<- prometheus.Metric, response *rpc.GetStackActivationResponse, pubkey string) {
+func (c *stakeactivationCollector) mustStakeActivationMetrics(ch chan<- prometheus.Metric, response *rpc.StakeActivationInfo, pubkey string) {
 
 	ch <- prometheus.MustNewConstMetric(c.active, prometheus.GaugeValue,
-		float64(response.Result.Active), pubkey)
+		float64(response.Active), pubkey)
 	ch <- prometheus.MustNewConstMetric(c.inactive, prometheus.GaugeValue,
-		float64(response.Result.Inactive), pubkey)
+		float64(response.Inactive), pubkey)
 	ch <- prometheus.MustNewConstMetric(c.state, prometheus.GaugeValue,
-		0, pubkey, response.Result.State)
+		0, pubkey, response.State)
 
 }
 
 func (c *stakeactivationCollector) Collect(ch chan<- prometheus.Metric) {
 
-	var myarr [2]string
-	myarr[0] = "AjuuY2XHwQoSufRW9ttiGGhnp6R5CxMKmhvEQpTWYjq3"
-	myarr[1] = "5Lpj3wJ34StS2Fd4AeR3fHRaA12JCZcwXSCn4KiKsZxh"
+	ctx, cancel := context.WithTimeout(context.Background(), httpTimeout)
+	defer cancel()
 
-	for i := 0; i < 2; i++ {
-		ctx, cancel := context.WithTimeout(context.Background(), httpTimeout)
-		defer cancel()
-		stakeactivationCollector, err := c.rpcClient.GetStackActivation(ctx, myarr[i])
-		klog.Infof("Get StackActivation Detail is: %v", stakeactivationCollector)
-		if err != nil {
-			ch <- prometheus.NewInvalidMetric(c.active, err)
-			ch <- prometheus.NewInvalidMetric(c.inactive, err)
-			ch <- prometheus.NewInvalidMetric(c.state, err)
+	pubkeys := c.config.Load().StakeAccounts
+	infos := make([]rpc.StakeActivationInfo, len(pubkeys))
+	calls := make([]*rpc.BatchCall, len(pubkeys))
+	for i, pubkey := range pubkeys {
+		calls[i] = rpc.NewGetStakeActivationCall(rpc.CommitmentRecent, pubkey, &infos[i])
+	}
+
+	if err := c.rpcClient.Batch(ctx, calls); err != nil {
+		ch <- prometheus.NewInvalidMetric(c.active, err)
+		ch <- prometheus.NewInvalidMetric(c.inactive, err)
+		ch <- prometheus.NewInvalidMetric(c.state, err)
+		return
+	}
+
+	for i, call := range calls {
+		klog.V(2).Infof("Get StackActivation Detail is: %v", infos[i])
+		if call.Err != nil {
+			ch <- prometheus.NewInvalidMetric(c.active, call.Err)
+			ch <- prometheus.NewInvalidMetric(c.inactive, call.Err)
+			ch <- prometheus.NewInvalidMetric(c.state, call.Err)
 		} else {
-			c.mustStakeActivationMetrics(ch, stakeactivationCollector, myarr[i])
+			c.mustStakeActivationMetrics(ch, &infos[i], pubkeys[i])
 		}
 	}
 }
 
 func (c *tokenaccountbyownerCollector) Collect(ch chan<- prometheus.Metric) {
 
-	var pubkeys [1]string
-	pubkeys[0] = "122FAHxVFQDQjzgSBSNUmLJXJxG4ooUwLdYvgf3ASs2K"
-
-	var mints [1]string
-	mints[0] = "4k3Dyjzvzp8eMZWUXbBCjEvwSkkk59S5iCNLY3QrkX6R"
-
-	for i := 0; i < len(pubkeys); i++ {
+	for _, owner := range c.config.Load().TokenOwners {
 		ctx, cancel := context.WithTimeout(context.Background(), httpTimeout)
-		defer cancel()
-		tokenaccsbyownerResp, err := c.rpcClient.GetTokenAccountOwner(ctx, pubkeys[i], mints[i])
-		klog.Infof("Get Account By Owner is: %v", tokenaccsbyownerResp)
+		tokenaccsbyownerResp, rctx, err := c.rpcClient.GetTokenAccountsByOwner(ctx, rpc.CommitmentRecent, owner.Pubkey, rpc.TokenAccountsFilter{Mint: owner.Mint})
+		cancel()
+		klog.V(2).Infof("Get Account By Owner is: %v", tokenaccsbyownerResp)
 		if err != nil {
 			ch <- prometheus.NewInvalidMetric(c.contextSlot, err)
 			ch <- prometheus.NewInvalidMetric(c.program, err)
@@ -819,42 +1050,84 @@ func (c *tokenaccountbyownerCollector) Collect(ch chan<- prometheus.Metric) {
 			ch <- prometheus.NewInvalidMetric(c.rentEpoch, err)
 
 		} else {
-			c.mustTokenAccByOwnerMetrics(ch, tokenaccsbyownerResp, pubkeys[i])
+			c.mustTokenAccByOwnerMetrics(ch, tokenaccsbyownerResp, rctx, owner)
 		}
 	}
 }
 
-func (c *tokensupplyCollector) Collect(ch chan<- prometheus.Metric) {
+func (c *tokenaccountbalanceCollector) Collect(ch chan<- prometheus.Metric) {
 
 	ctx, cancel := context.WithTimeout(context.Background(), httpTimeout)
 	defer cancel()
 
-	tokensupplyres, err := c.rpcClient.GetTokenSupply(ctx, "JCHsvHwF6TgeM1fapxgAkhVKDU5QtPox3bfCR5sjWirP")
-	klog.Infof("Token Supply value is: %v", tokensupplyres)
+	pubkeys := c.config.Load().TokenAccounts
+	balances := make([]rpc.TokenAmount, len(pubkeys))
+	calls := make([]*rpc.BatchCall, len(pubkeys))
+	for i, pubkey := range pubkeys {
+		calls[i] = rpc.NewGetTokenAccountBalanceCall(rpc.CommitmentRecent, pubkey, &balances[i])
+	}
 
-	if err != nil {
+	if err := c.rpcClient.Batch(ctx, calls); err != nil {
 		ch <- prometheus.NewInvalidMetric(c.contextSlot, err)
 		ch <- prometheus.NewInvalidMetric(c.amount, err)
 		ch <- prometheus.NewInvalidMetric(c.decimals, err)
 		ch <- prometheus.NewInvalidMetric(c.uiAmount, err)
-		ch <- prometheus.NewInvalidMetric(c.uiAmountString, err)
-	} else {
-		c.mustTokenSupplyMetrics(ch, tokensupplyres, "JCHsvHwF6TgeM1fapxgAkhVKDU5QtPox3bfCR5sjWirP")
+		return
+	}
+
+	for i, call := range calls {
+		if call.Err != nil {
+			ch <- prometheus.NewInvalidMetric(c.contextSlot, call.Err)
+			ch <- prometheus.NewInvalidMetric(c.amount, call.Err)
+			ch <- prometheus.NewInvalidMetric(c.decimals, call.Err)
+			ch <- prometheus.NewInvalidMetric(c.uiAmount, call.Err)
+		} else {
+			c.mustTokenAccountBalanceMetrics(ch, &balances[i], call.Context(), pubkeys[i])
+		}
 	}
 }
 
-func (c *accountinfobase64Collector) Collect(ch chan<- prometheus.Metric) {
+func (c *tokensupplyCollector) Collect(ch chan<- prometheus.Metric) {
 
-	var myarr [2]string
-	myarr[0] = "AjuuY2XHwQoSufRW9ttiGGhnp6R5CxMKmhvEQpTWYjq3"
-	myarr[1] = "5Lpj3wJ34StS2Fd4AeR3fHRaA12JCZcwXSCn4KiKsZxh"
+	ctx, cancel := context.WithTimeout(context.Background(), httpTimeout)
+	defer cancel()
+
+	for _, mint := range c.config.Load().TokenMints {
+		tokensupplyres, rctx, err := c.rpcClient.GetTokenSupply(ctx, rpc.CommitmentRecent, mint)
+		klog.V(2).Infof("Token Supply value is: %v", tokensupplyres)
 
-	for i := 0; i < len(myarr); i++ {
-		ctx, cancel := context.WithTimeout(context.Background(), httpTimeout)
-		defer cancel()
-		accountinfobase64res, err := c.rpcClient.GetAccountInfoBase64(ctx, myarr[i])
-		klog.Infof("Get Account Info Base64 Detail is: %v", accountinfobase64res)
 		if err != nil {
+			ch <- prometheus.NewInvalidMetric(c.contextSlot, err)
+			ch <- prometheus.NewInvalidMetric(c.amount, err)
+			ch <- prometheus.NewInvalidMetric(c.decimals, err)
+			ch <- prometheus.NewInvalidMetric(c.uiAmount, err)
+			ch <- prometheus.NewInvalidMetric(c.uiAmountString, err)
+		} else {
+			c.mustTokenSupplyMetrics(ch, tokensupplyres, rctx, mint)
+		}
+	}
+}
+
+func (c *accountinfobase64Collector) Collect(ch chan<- prometheus.Metric) {
+
+	ctx, cancel := context.WithTimeout(context.Background(), httpTimeout)
+	defer cancel()
+
+	pubkeys := c.config.Load().AccountInfos
+	infos, calls, err := fetchAccountInfos(ctx, c.rpcClient, pubkeys, rpc.EncodingBase64)
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(c.contextSlot, err)
+		ch <- prometheus.NewInvalidMetric(c.data, err)
+		ch <- prometheus.NewInvalidMetric(c.executable, err)
+		ch <- prometheus.NewInvalidMetric(c.lamports, err)
+		ch <- prometheus.NewInvalidMetric(c.owner, err)
+		ch <- prometheus.NewInvalidMetric(c.rentEpoch, err)
+		return
+	}
+
+	for i, call := range calls {
+		klog.V(2).Infof("Get Account Info Base64 Detail is: %v", infos[i])
+		if err := call.Err; err != nil {
 			ch <- prometheus.NewInvalidMetric(c.contextSlot, err)
 			ch <- prometheus.NewInvalidMetric(c.data, err)
 			ch <- prometheus.NewInvalidMetric(c.executable, err)
@@ -862,23 +1135,33 @@ func (c *accountinfobase64Collector) Collect(ch chan<- prometheus.Metric) {
 			ch <- prometheus.NewInvalidMetric(c.owner, err)
 			ch <- prometheus.NewInvalidMetric(c.rentEpoch, err)
 		} else {
-			c.mustAccountInfo64Metric(ch, accountinfobase64res, myarr[i])
+			c.mustAccountInfo64Metric(ch, infos[i], call.Context(), pubkeys[i])
 		}
 	}
 }
 
 func (c *getaccountinfojsonparsedCollector) Collect(ch chan<- prometheus.Metric) {
 
-	var myarr [2]string
-	myarr[0] = "AjuuY2XHwQoSufRW9ttiGGhnp6R5CxMKmhvEQpTWYjq3"
-	myarr[1] = "5Lpj3wJ34StS2Fd4AeR3fHRaA12JCZcwXSCn4KiKsZxh"
+	ctx, cancel := context.WithTimeout(context.Background(), httpTimeout)
+	defer cancel()
 
-	for i := 0; i < len(myarr); i++ {
-		ctx, cancel := context.WithTimeout(context.Background(), httpTimeout)
-		defer cancel()
-		accjsonparseres, err := c.rpcClient.GetAccountInfoJsonParsed(ctx, myarr[i])
-		klog.Infof("Get Account Info Json Parsed Detail is: %v", accjsonparseres)
-		if err != nil {
+	pubkeys := c.config.Load().AccountInfos
+	infos, calls, err := fetchAccountInfos(ctx, c.rpcClient, pubkeys, rpc.EncodingJSONParsed)
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(c.contextSlot, err)
+		ch <- prometheus.NewInvalidMetric(c.authority, err)
+		ch <- prometheus.NewInvalidMetric(c.blockhash, err)
+		ch <- prometheus.NewInvalidMetric(c.executable, err)
+		ch <- prometheus.NewInvalidMetric(c.lamportsPerSignature, err)
+		ch <- prometheus.NewInvalidMetric(c.lamports, err)
+		ch <- prometheus.NewInvalidMetric(c.owner, err)
+		ch <- prometheus.NewInvalidMetric(c.rentEpoch, err)
+		return
+	}
+
+	for i, call := range calls {
+		klog.V(2).Infof("Get Account Info Json Parsed Detail is: %v", infos[i])
+		if err := call.Err; err != nil {
 			ch <- prometheus.NewInvalidMetric(c.contextSlot, err)
 			ch <- prometheus.NewInvalidMetric(c.authority, err)
 			ch <- prometheus.NewInvalidMetric(c.blockhash, err)
@@ -888,11 +1171,32 @@ func (c *getaccountinfojsonparsedCollector) Collect(ch chan<- prometheus.Metric)
 			ch <- prometheus.NewInvalidMetric(c.owner, err)
 			ch <- prometheus.NewInvalidMetric(c.rentEpoch, err)
 		} else {
-			c.mustAccountInfoJsonParsedCollector(ch, accjsonparseres, myarr[i])
+			c.mustAccountInfoJsonParsedCollector(ch, infos[i], call.Context(), pubkeys[i])
 		}
 	}
 }
 
+// fetchAccountInfos requests all pubkeys in a single batch. Calls for accounts that do not exist are marked as failed.
+func fetchAccountInfos(ctx context.Context, client *rpc.RPCClient, pubkeys []string, encoding rpc.Encoding) ([]*rpc.AccountInfo, []*rpc.BatchCall, error) {
+	infos := make([]*rpc.AccountInfo, len(pubkeys))
+	calls := make([]*rpc.BatchCall, len(pubkeys))
+	for i, pubkey := range pubkeys {
+		calls[i] = rpc.NewGetAccountInfoCall(rpc.CommitmentRecent, pubkey, encoding, &infos[i])
+	}
+
+	if err := client.Batch(ctx, calls); err != nil {
+		return nil, nil, err
+	}
+
+	for i, call := range calls {
+		if call.Err == nil && infos[i] == nil {
+			call.Err = fmt.Errorf("account %s not found", pubkeys[i])
+		}
+	}
+
+	return infos, calls, nil
+}
+
 func main() {
 	flag.Parse()
 
@@ -900,29 +1204,96 @@ func main() {
 		klog.Fatal("Please specify -rpcURI")
 	}
 
-	collector := NewSolanaCollector(*rpcAddr)
-	sCollector := NewSupplyCollector(*rpcAddr)
-	accountCollector := NewAccCollector(*rpcAddr)
-	balanceCollector := NewBalanceCollector(*rpcAddr)
-	tokenaccbyownerCollector := NewTokenAccByOwnerCollector(*rpcAddr)
-	tokensupplyCollector := NewTokenSuppyCollector(*rpcAddr)
-	stakeactivationCollector := NewStakeActivationCollector(*rpcAddr)
-	accountinfobase64Collector := NewAccountInfoCollector(*rpcAddr)
-	accountinfojsonparsedCollector := NewAccountInfoJsonParsedCollector(*rpcAddr)
-
-	go collector.WatchSlots()
-
-	prometheus.MustRegister(collector)
-	prometheus.MustRegister(sCollector)
-	prometheus.MustRegister(accountCollector)
-	prometheus.MustRegister(balanceCollector)
-	prometheus.MustRegister(tokenaccbyownerCollector)
-	prometheus.MustRegister(tokensupplyCollector)
-	prometheus.MustRegister(stakeactivationCollector)
-	prometheus.MustRegister(accountinfobase64Collector)
-	prometheus.MustRegister(accountinfojsonparsedCollector)
+	config, err := newConfigStore(*configPath)
+	if err != nil {
+		klog.Fatal(err)
+	}
+
+	endpoints, err := rpc.ParseEndpoints(*rpcAddr)
+	if err != nil {
+		klog.Fatal(err)
+	}
+	// The endpoint pool is fixed at startup; node_ip changes need a restart.
+	nodes, err := config.Load().nodeEndpoints()
+	if err != nil {
+		klog.Fatal(err)
+	}
+	known := make(map[string]bool, len(endpoints))
+	for _, endpoint := range endpoints {
+		known[endpoint] = true
+	}
+	for _, node := range nodes {
+		if !known[node] {
+			endpoints = append(endpoints, node)
+		}
+	}
+
+	client := rpc.NewRPCClient(endpoints...)
+	client.OnRequest = observeRPCRequest
+	client.OnHealthCheck = observeRPCHealth
+	client.Retry.MaxAttempts = *rpcMaxAttempts
+	client.Retry.InitialBackoff = *rpcRetryBackoff
+	if client.Retry.MaxBackoff < *rpcRetryBackoff {
+		client.Retry.MaxBackoff = *rpcRetryBackoff
+	}
+
+	collector := NewSolanaCollector(client, config)
+	sCollector := NewSupplyCollector(client)
+	accountCollector := NewAccCollector(client)
+	balanceCollector := NewBalanceCollector(client, config)
+	tokenaccbyownerCollector := NewTokenAccByOwnerCollector(client, config)
+	tokenaccountbalanceCollector := NewTokenAccountBalanceCollector(client, config)
+	tokensupplyCollector := NewTokenSuppyCollector(client, config)
+	stakeactivationCollector := NewStakeActivationCollector(client, config)
+	accountinfobase64Collector := NewAccountInfoCollector(client, config)
+	accountinfojsonparsedCollector := NewAccountInfoJsonParsedCollector(client, config)
+	clusterNodesCollector := NewClusterNodesCollector(client, config, collector.latestVoteAccounts)
+	performanceCollector := NewPerformanceCollector(client)
+
+	go client.WatchHealth(context.Background(), healthCheckInterval, *maxSlotLag)
+	var roots <-chan int64
+	if *wsAddr != "" {
+		pubsub := rpc.NewPubSubClient(*wsAddr)
+		pubsub.OnConnectionState = observePubSubConnection
+		roots = pubsub.RootSubscribe().C
+		go pubsub.Run(context.Background())
+	}
+
+	go collector.WatchSlots(roots, *statePath)
+	collector.RunPollers()
+	if *probeInterval > 0 {
+		go (&prober{rpcClient: client, config: config}).Run(*probeInterval)
+	}
+
+	cache := NewCachedCollector()
+	cache.Add("validators", collector)
+	cache.Add("supply", sCollector)
+	cache.Add("largest_accounts", accountCollector)
+	cache.Add("balance", balanceCollector)
+	cache.Add("token_accounts_by_owner", tokenaccbyownerCollector)
+	cache.Add("token_account_balance", tokenaccountbalanceCollector)
+	cache.Add("token_supply", tokensupplyCollector)
+	cache.Add("stake_activation", stakeactivationCollector)
+	cache.Add("account_info_base64", accountinfobase64Collector)
+	cache.Add("account_info_json_parsed", accountinfojsonparsedCollector)
+	cache.Add("cluster_nodes", clusterNodesCollector)
+	cache.Add("performance", performanceCollector)
+	intervals, err := parseIntervals(*collectorIntervals)
+	if err != nil {
+		klog.Fatal(err)
+	}
+	if err := cache.SetIntervals(intervals); err != nil {
+		klog.Fatalf("-collectorRefreshIntervals: %v", err)
+	}
+	prometheus.MustRegister(cache)
+	go cache.Run(*refreshInterval)
+
+	go config.WatchSIGHUP()
 
 	http.Handle("/metrics", promhttp.Handler())
+	http.Handle("/-/reload", config)
+	http.Handle("/api/v1/maintenance-windows", &maintenanceWindowsHandler{leaders: collector.leaders})
+	http.Handle("/api/v1/commission-changes", &collector.commission)
 
 	klog.Infof("listening on %s", *addr)
 	klog.Fatal(http.ListenAndServe(*addr, nil))
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/certusone/solana_exporter/pkg/rpc"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// fakeRPC is a JSON-RPC server answering with a handler, and recording the method and params of every call.
+type fakeRPC struct {
+	*httptest.Server
+
+	mu    sync.Mutex
+	calls []fakeCall
+}
+
+type fakeCall struct {
+	method string
+	params []interface{}
+}
+
+// newFakeRPC starts a server answering calls with respond. If respond returns an *rpc.Error, it is sent as the
+// error of the call.
+func newFakeRPC(t *testing.T, respond func(method string, params []interface{}) (interface{}, *rpc.Error)) *fakeRPC {
+	f := &fakeRPC{}
+	f.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     int           `json:"id"`
+			Method string        `json:"method"`
+			Params []interface{} `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("invalid request: %v", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		f.mu.Lock()
+		f.calls = append(f.calls, fakeCall{req.Method, req.Params})
+		f.mu.Unlock()
+
+		resp := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+		if result, rpcErr := respond(req.Method, req.Params); rpcErr != nil {
+			resp["error"] = rpcErr
+		} else {
+			resp["result"] = result
+		}
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			t.Errorf("failed to encode response: %v", err)
+		}
+	}))
+	return f
+}
+
+// slotParams returns the first param of every call of method, as slots.
+func (f *fakeRPC) slotParams(method string) []int64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var slots []int64
+	for _, call := range f.calls {
+		if call.method == method && len(call.params) > 0 {
+			slots = append(slots, int64(call.params[0].(float64)))
+		}
+	}
+	return slots
+}
+
+// slotRanges returns the first two params of every call of method, as slot ranges.
+func (f *fakeRPC) slotRanges(method string) [][2]int64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var ranges [][2]int64
+	for _, call := range f.calls {
+		if call.method == method && len(call.params) > 1 {
+			ranges = append(ranges, [2]int64{int64(call.params[0].(float64)), int64(call.params[1].(float64))})
+		}
+	}
+	return ranges
+}
+
+// contextValue wraps value in the envelope of methods returning a context.
+func contextValue(slot int64, value interface{}) interface{} {
+	return map[string]interface{}{"context": map[string]int64{"slot": slot}, "value": value}
+}
+
+// gaugeValues returns the values of the series of g by their label values, joined with commas.
+func gaugeValues(t *testing.T, g *prometheus.GaugeVec) map[string]float64 {
+	ch := make(chan prometheus.Metric, 100)
+	g.Collect(ch)
+	close(ch)
+
+	values := make(map[string]float64)
+	for metric := range ch {
+		var m dto.Metric
+		if err := metric.Write(&m); err != nil {
+			t.Fatal(err)
+		}
+		var labels []string
+		for _, label := range m.GetLabel() {
+			labels = append(labels, label.GetValue())
+		}
+		values[strings.Join(labels, ",")] = m.GetGauge().GetValue()
+	}
+	return values
+}
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/certusone/solana_exporter/pkg/rpc"
+)
+
+const (
+	// Number of consecutive slots assigned to a leader at once.
+	leaderWindowSlots = 4
+
+	// Slot duration assumed until enough slots were observed to measure it.
+	defaultSlotDuration = 400 * time.Millisecond
+	// Period over which the slot duration is measured.
+	slotDurationWindow = 10 * time.Minute
+	// Minimum period of observations required to measure the slot duration.
+	minSlotDurationWindow = 30 * time.Second
+)
+
+type (
+	// leaderTracker keeps the leader schedules of the current and the next epoch by identity, and estimates when
+	// upcoming slots occur from the measured slot duration. It is updated by WatchSlots.
+	leaderTracker struct {
+		mu        sync.RWMutex
+		schedules map[int64]*identitySchedule
+		// Latest confirmed slot and the time it was observed.
+		slot       int64
+		observedAt time.Time
+		samples    []slotSample
+	}
+
+	// identitySchedule lists the leader slots of an epoch by identity, in ascending order.
+	identitySchedule struct {
+		firstSlot int64
+		lastSlot  int64
+		slots     map[string][]int64
+	}
+
+	slotSample struct {
+		slot int64
+		at   time.Time
+	}
+)
+
+func newLeaderTracker() *leaderTracker {
+	return &leaderTracker{schedules: make(map[int64]*identitySchedule)}
+}
+
+// setSchedule stores the leader schedule of the epoch from firstSlot to lastSlot. leaders maps slot indexes relative
+// to firstSlot to identities, as returned by fetchLeaderSlots.
+func (t *leaderTracker) setSchedule(epoch, firstSlot, lastSlot int64, leaders map[int64]string) {
+	s := &identitySchedule{firstSlot: firstSlot, lastSlot: lastSlot, slots: make(map[string][]int64)}
+	for i, leader := range leaders {
+		s.slots[leader] = append(s.slots[leader], firstSlot+i)
+	}
+	for _, slots := range s.slots {
+		sort.Slice(slots, func(i, j int) bool { return slots[i] < slots[j] })
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.schedules[epoch] = s
+	// Only the current epoch and the one after it are ever looked at.
+	for e := range t.schedules {
+		if e < epoch-1 {
+			delete(t.schedules, e)
+		}
+	}
+}
+
+// hasSchedule reports whether the schedule of epoch is known.
+func (t *leaderTracker) hasSchedule(epoch int64) bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	_, ok := t.schedules[epoch]
+	return ok
+}
+
+// observe records that slot was the latest confirmed slot at time at.
+func (t *leaderTracker) observe(slot int64, at time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if slot <= t.slot {
+		return
+	}
+	t.slot, t.observedAt = slot, at
+
+	t.samples = append(t.samples, slotSample{slot, at})
+	i := 0
+	for i < len(t.samples)-1 && at.Sub(t.samples[i].at) > slotDurationWindow {
+		i++
+	}
+	t.samples = t.samples[i:]
+}
+
+// slotDuration returns the average slot duration over the last slotDurationWindow, or defaultSlotDuration if too few
+// slots were observed yet.
+func (t *leaderTracker) slotDuration() time.Duration {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.slotDurationLocked()
+}
+
+func (t *leaderTracker) slotDurationLocked() time.Duration {
+	if len(t.samples) < 2 {
+		return defaultSlotDuration
+	}
+	first, last := t.samples[0], t.samples[len(t.samples)-1]
+	elapsed := last.at.Sub(first.at)
+	if elapsed < minSlotDurationWindow || last.slot <= first.slot {
+		return defaultSlotDuration
+	}
+	return elapsed / time.Duration(last.slot-first.slot)
+}
+
+// upcomingLeaderSlots lists the known leader slots of identity after the latest confirmed slot, along with that slot
+// and the first slot of the epoch each leader slot belongs to.
+func (t *leaderTracker) upcomingLeaderSlots(identity string) (current int64, slots []int64, epochFirst []int64) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.upcomingLeaderSlotsLocked(identity)
+}
+
+func (t *leaderTracker) upcomingLeaderSlotsLocked(identity string) (current int64, slots []int64, epochFirst []int64) {
+	epochs := make([]int64, 0, len(t.schedules))
+	for e := range t.schedules {
+		epochs = append(epochs, e)
+	}
+	sort.Slice(epochs, func(i, j int) bool { return epochs[i] < epochs[j] })
+
+	for _, e := range epochs {
+		s := t.schedules[e]
+		all := s.slots[identity]
+		for _, slot := range all[sort.Search(len(all), func(i int) bool { return all[i] > t.slot }):] {
+			slots = append(slots, slot)
+			epochFirst = append(epochFirst, s.firstSlot)
+		}
+	}
+	return t.slot, slots, epochFirst
+}
+
+// estimate returns the estimated time at which slot starts.
+func (t *leaderTracker) estimate(slot int64) time.Time {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.estimateLocked(slot)
+}
+
+func (t *leaderTracker) estimateLocked(slot int64) time.Time {
+	return t.observedAt.Add(time.Duration(slot-t.slot) * t.slotDurationLocked())
+}
+
+// export updates the leader slot gauges of the tracked identities. currentLast is the last slot of the current epoch.
+func (t *leaderTracker) export(identities []string, currentLast int64, now time.Time) {
+	slotDuration.WithLabelValues("slot_height", "10m").Set(t.slotDuration().Seconds())
+
+	trackedNextLeaderSlot.Reset()
+	trackedNextLeaderSlotSeconds.Reset()
+	trackedLeaderSlotsUpcoming.Reset()
+	trackedLeaderWindowsUpcoming.Reset()
+
+	for _, identity := range identities {
+		current, slots, epochFirst := t.upcomingLeaderSlots(identity)
+
+		var upcoming, windows int64
+		lastWindow := int64(-1)
+		for i, slot := range slots {
+			if slot > currentLast {
+				break
+			}
+			upcoming++
+			// Only count windows that did not start yet.
+			window := epochFirst[i] + (slot-epochFirst[i])/leaderWindowSlots*leaderWindowSlots
+			if window > current && window != lastWindow {
+				windows++
+				lastWindow = window
+			}
+		}
+		trackedLeaderSlotsUpcoming.WithLabelValues(identity).Set(float64(upcoming))
+		trackedLeaderWindowsUpcoming.WithLabelValues(identity).Set(float64(windows))
+
+		if len(slots) > 0 {
+			next := slots[0]
+			until := t.estimate(next).Sub(now)
+			if until < 0 {
+				until = 0
+			}
+			trackedNextLeaderSlot.WithLabelValues(identity).Set(float64(next))
+			trackedNextLeaderSlotSeconds.WithLabelValues(identity).Set(until.Seconds())
+		}
+	}
+}
+
+// trackLeaders updates the tracker after WatchSlots observed info, making sure the schedules of the current and the
+// next epoch are loaded.
+func (c *solanaCollector) trackLeaders(info *rpc.EpochInfo, epochSchedule *rpc.EpochScheduleInfo, schedules map[int64]map[int64]string) {
+	_, _, lastSlot := epochSchedule.Epoch(info.AbsoluteSlot)
+	for _, slot := range []int64{info.AbsoluteSlot, lastSlot + 1} {
+		epoch, first, last := epochSchedule.Epoch(slot)
+		if c.leaders.hasSchedule(epoch) {
+			continue
+		}
+		if leaders, ok := schedules[epoch]; ok && len(leaders) > 0 {
+			c.leaders.setSchedule(epoch, first, last, leaders)
+		}
+	}
+
+	c.leaders.export(c.config.Load().TrackedIdentities, lastSlot, time.Now())
+}
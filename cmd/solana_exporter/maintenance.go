@@ -0,0 +1,148 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+type (
+	// maintenanceWindowsHandler serves GET /api/v1/maintenance-windows, listing the upcoming gaps between the leader
+	// slots of a validator that are long enough to restart it.
+	maintenanceWindowsHandler struct {
+		leaders *leaderTracker
+	}
+
+	maintenancePlan struct {
+		Identity            string  `json:"identity"`
+		Slot                int64   `json:"slot"`
+		SlotDurationSeconds float64 `json:"slot_duration_seconds"`
+		// Last slot covered by the known leader schedules.
+		ScheduleLastSlot int64               `json:"schedule_last_slot"`
+		LeaderSlots      int                 `json:"leader_slots"`
+		Windows          []maintenanceWindow `json:"windows"`
+	}
+
+	// maintenanceWindow is a range of slots without leader slots of the validator.
+	maintenanceWindow struct {
+		FirstSlot int64     `json:"first_slot"`
+		LastSlot  int64     `json:"last_slot"`
+		Start     time.Time `json:"start"`
+		End       time.Time `json:"end"`
+		Minutes   float64   `json:"minutes"`
+		// Set on a window reaching the end of the known leader schedules, which may turn out longer.
+		OpenEnded bool `json:"open_ended,omitempty"`
+	}
+)
+
+// maintenancePlan lists the gaps of at least minDuration between the leader slots of identity, from the latest
+// confirmed slot to the end of the known leader schedules. It returns false if no schedule is known yet.
+func (t *leaderTracker) maintenancePlan(identity string, minDuration time.Duration) (*maintenancePlan, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	epochs := make([]int64, 0, len(t.schedules))
+	for e := range t.schedules {
+		epochs = append(epochs, e)
+	}
+	sort.Slice(epochs, func(i, j int) bool { return epochs[i] < epochs[j] })
+
+	// Only consider the schedules covering the slots after the confirmed one without interruption.
+	horizon := t.slot
+	for _, e := range epochs {
+		s := t.schedules[e]
+		if s.lastSlot <= horizon {
+			continue
+		}
+		if s.firstSlot > horizon+1 {
+			break
+		}
+		horizon = s.lastSlot
+	}
+	if t.slot == 0 || horizon == t.slot {
+		return nil, false
+	}
+
+	current, slots, _ := t.upcomingLeaderSlotsLocked(identity)
+	slotDuration := t.slotDurationLocked()
+	plan := &maintenancePlan{
+		Identity:            identity,
+		Slot:                current,
+		SlotDurationSeconds: slotDuration.Seconds(),
+		ScheduleLastSlot:    horizon,
+		Windows:             []maintenanceWindow{},
+	}
+
+	add := func(first, last int64, openEnded bool) {
+		if time.Duration(last-first+1)*slotDuration < minDuration {
+			return
+		}
+		start, end := t.estimateLocked(first), t.estimateLocked(last+1)
+		plan.Windows = append(plan.Windows, maintenanceWindow{
+			FirstSlot: first,
+			LastSlot:  last,
+			Start:     start,
+			End:       end,
+			Minutes:   end.Sub(start).Minutes(),
+			OpenEnded: openEnded,
+		})
+	}
+
+	prev := current
+	for _, slot := range slots {
+		if slot > horizon {
+			break
+		}
+		plan.LeaderSlots++
+		if slot > prev+1 {
+			add(prev+1, slot-1, false)
+		}
+		prev = slot
+	}
+	if horizon > prev {
+		add(prev+1, horizon, true)
+	}
+
+	return plan, true
+}
+
+func (h *maintenanceWindowsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "only GET requests allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	identity := r.URL.Query().Get("identity")
+	if err := validatePubkey(identity); err != nil {
+		http.Error(w, fmt.Sprintf("identity: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	var minMinutes float64
+	if v := r.URL.Query().Get("min_minutes"); v != "" {
+		var err error
+		minMinutes, err = strconv.ParseFloat(v, 64)
+		if err != nil || minMinutes < 0 || math.IsNaN(minMinutes) || math.IsInf(minMinutes, 0) {
+			http.Error(w, fmt.Sprintf("min_minutes: invalid number of minutes %q", v), http.StatusBadRequest)
+			return
+		}
+	}
+
+	plan, ok := h.leaders.maintenancePlan(identity, time.Duration(minMinutes*float64(time.Minute)))
+	if !ok {
+		http.Error(w, "leader schedule not loaded yet", http.StatusServiceUnavailable)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(plan); err != nil {
+		klog.Errorf("failed to write maintenance windows: %v", err)
+	}
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// testSchedule returns the leader schedule of an epoch of 100 slots, where identity leads the slots at indexes and
+// another validator all the others.
+func testSchedule(identity string, indexes ...int64) map[int64]string {
+	leaders := make(map[int64]string, 100)
+	for i := int64(0); i < 100; i++ {
+		leaders[i] = "other"
+	}
+	for _, i := range indexes {
+		leaders[i] = identity
+	}
+	return leaders
+}
+
+func TestMaintenancePlan(t *testing.T) {
+	const identity = "validator"
+	type window struct {
+		first, last int64
+		openEnded   bool
+	}
+
+	for _, tt := range []struct {
+		name string
+		// Leader slot indexes of identity in epoch 10 (slots 1000 to 1099), and in epoch 11 if set.
+		epoch10, epoch11 []int64
+		next             bool
+		slot             int64
+		minDuration      time.Duration
+
+		ok          bool
+		horizon     int64
+		leaderSlots int
+		windows     []window
+	}{
+		{
+			name:        "gaps between leader windows",
+			epoch10:     []int64{20, 21, 22, 23, 60, 61, 62, 63},
+			slot:        1010,
+			ok:          true,
+			horizon:     1099,
+			leaderSlots: 8,
+			windows:     []window{{1011, 1019, false}, {1024, 1059, false}, {1064, 1099, true}},
+		},
+		{
+			name:    "minimum duration",
+			epoch10: []int64{20, 21, 22, 23, 60, 61, 62, 63},
+			slot:    1010,
+			// 25 slots of 400ms.
+			minDuration: 10 * time.Second,
+			ok:          true,
+			horizon:     1099,
+			leaderSlots: 8,
+			windows:     []window{{1024, 1059, false}, {1064, 1099, true}},
+		},
+		{
+			name:        "confirmed slot within a leader window",
+			epoch10:     []int64{20, 21, 22, 23, 60, 61, 62, 63},
+			slot:        1021,
+			ok:          true,
+			horizon:     1099,
+			leaderSlots: 6,
+			windows:     []window{{1024, 1059, false}, {1064, 1099, true}},
+		},
+		{
+			name:        "next epoch extends the horizon",
+			epoch10:     []int64{60, 61, 62, 63},
+			epoch11:     []int64{50, 51, 52, 53},
+			next:        true,
+			slot:        1010,
+			ok:          true,
+			horizon:     1199,
+			leaderSlots: 8,
+			windows:     []window{{1011, 1059, false}, {1064, 1149, false}, {1154, 1199, true}},
+		},
+		{
+			name:        "no leader slots",
+			slot:        1010,
+			ok:          true,
+			horizon:     1099,
+			leaderSlots: 0,
+			windows:     []window{{1011, 1099, true}},
+		},
+		{
+			name:        "leader slot at the horizon",
+			epoch10:     []int64{96, 97, 98, 99},
+			slot:        1094,
+			ok:          true,
+			horizon:     1099,
+			leaderSlots: 4,
+			windows:     []window{{1095, 1095, false}},
+		},
+		{
+			name: "confirmed slot at the end of the known schedules",
+			slot: 1099,
+		},
+		{
+			name: "confirmed slot unknown",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Unix(1600000000, 0)
+			tracker := newLeaderTracker()
+			tracker.setSchedule(10, 1000, 1099, testSchedule(identity, tt.epoch10...))
+			if tt.next {
+				tracker.setSchedule(11, 1100, 1199, testSchedule(identity, tt.epoch11...))
+			}
+			if tt.slot != 0 {
+				tracker.observe(tt.slot, now)
+			}
+
+			plan, ok := tracker.maintenancePlan(identity, tt.minDuration)
+			if ok != tt.ok {
+				t.Fatalf("maintenancePlan returned %v, want %v", ok, tt.ok)
+			}
+			if !ok {
+				return
+			}
+			if plan.Slot != tt.slot || plan.ScheduleLastSlot != tt.horizon || plan.LeaderSlots != tt.leaderSlots {
+				t.Errorf("got slot %d, horizon %d and %d leader slots; want %d, %d and %d",
+					plan.Slot, plan.ScheduleLastSlot, plan.LeaderSlots, tt.slot, tt.horizon, tt.leaderSlots)
+			}
+
+			var windows []window
+			for _, w := range plan.Windows {
+				windows = append(windows, window{w.FirstSlot, w.LastSlot, w.OpenEnded})
+
+				// Windows start with their first slot and end with the start of the slot after the last one.
+				wantStart := now.Add(time.Duration(w.FirstSlot-tt.slot) * defaultSlotDuration)
+				wantEnd := now.Add(time.Duration(w.LastSlot+1-tt.slot) * defaultSlotDuration)
+				if !w.Start.Equal(wantStart) || !w.End.Equal(wantEnd) || w.Minutes != wantEnd.Sub(wantStart).Minutes() {
+					t.Errorf("window %d-%d from %v to %v (%v minutes), want %v to %v",
+						w.FirstSlot, w.LastSlot, w.Start, w.End, w.Minutes, wantStart, wantEnd)
+				}
+			}
+			if !reflect.DeepEqual(windows, tt.windows) {
+				t.Errorf("got windows %v, want %v", windows, tt.windows)
+			}
+		})
+	}
+}
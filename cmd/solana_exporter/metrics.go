@@ -8,6 +8,9 @@ import (
 
 const (
 	slotPacerSchedule = 1 * time.Second
+	// Used instead of slotPacerSchedule when slots are tracked on root notifications, to keep going while the
+	// PubSub connection is down.
+	slotPacerFallback = 10 * time.Second
 )
 
 var (
@@ -41,8 +44,272 @@ var (
 			Name: "solana_leader_slots_total",
 			Help: "Number of leader slots per leader, grouped by skip status (max confirmation)",
 		},
-		[]string{"status", "nodekey"},
-	)
+		[]string{"status", "nodekey"})
+
+	leaderSlotsAssigned = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "solana_validator_leader_slots_assigned",
+			Help: "Number of leader slots assigned to each leader in the current epoch",
+		},
+		[]string{"nodekey"})
+
+	leaderSlotsProduced = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "solana_validator_leader_slots_produced",
+			Help: "Number of blocks produced by each leader in the current epoch (max confirmation)",
+		},
+		[]string{"nodekey"})
+
+	leaderSlotsSkipped = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "solana_validator_leader_slots_skipped",
+			Help: "Number of leader slots skipped by each leader in the current epoch (max confirmation)",
+		},
+		[]string{"nodekey"})
+
+	leaderSlotsRemaining = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "solana_validator_leader_slots_remaining",
+			Help: "Number of leader slots of each leader in the current epoch that were not accounted for yet",
+		},
+		[]string{"nodekey"})
+
+	leaderSkipRate = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "solana_validator_leader_slots_skip_rate",
+			Help: "Ratio of skipped to past leader slots of each leader in the current epoch",
+		},
+		[]string{"nodekey"})
+
+	trackedNextLeaderSlot = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "solana_validator_next_leader_slot",
+			Help: "Next leader slot of each tracked validator",
+		},
+		[]string{"nodekey"})
+
+	trackedNextLeaderSlotSeconds = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "solana_validator_next_leader_slot_seconds",
+			Help: "Estimated number of seconds until the next leader slot of each tracked validator",
+		},
+		[]string{"nodekey"})
+
+	trackedLeaderSlotsUpcoming = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "solana_validator_leader_slots_upcoming",
+			Help: "Number of leader slots of each tracked validator after the confirmed slot in the current epoch",
+		},
+		[]string{"nodekey"})
+
+	trackedLeaderWindowsUpcoming = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "solana_validator_leader_windows_upcoming",
+			Help: "Number of leader windows of each tracked validator that did not start yet in the current epoch",
+		},
+		[]string{"nodekey"})
+
+	blockTimeDrift = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "solana_block_time_drift_seconds",
+			Help: "Difference between the wall clock and the block time of the latest confirmed block",
+		})
+
+	epochProjectedEnd = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "solana_epoch_projected_end_timestamp_seconds",
+			Help: "Projected time at which the current epoch ends, from the remaining slots and the average slot duration",
+		})
+
+	commissionChangesTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "solana_validator_commission_changes_total",
+			Help: "Number of commission changes of each validator observed since startup",
+		},
+		[]string{"votekey", "nodekey"})
+
+	probeReachable = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "solana_validator_endpoint_reachable",
+			Help: "Whether an endpoint a tracked validator advertises in gossip was reachable at the last probe",
+		},
+		[]string{"nodekey", "endpoint", "address"})
+
+	probeLatency = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "solana_validator_endpoint_probe_latency_seconds",
+			Help: "Duration of the last successful probe of an endpoint a tracked validator advertises in gossip",
+		},
+		[]string{"nodekey", "endpoint", "address"})
+
+	slotDuration = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "solana_slot_duration_seconds",
+			Help: "Average slot duration over a sliding window, measured from the confirmed slot height or from block times",
+		},
+		[]string{"source", "window"})
+
+	getHealth = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "solana_health",
+		Help: "Current Health",
+	})
+
+	getFirstAvailableBlock = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "first_available_block",
+		Help: "Current First_Block",
+	})
+
+	getInflationEpoch = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "Infaltion_Epoch",
+		Help: "Current Infaltion_Epoch",
+	})
+
+	getInfaltionFoundation = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "Infaltion_Foundation",
+		Help: "Current Inflation foundation",
+	})
+
+	getInfaltionTotal = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "Infaltion_Total",
+		Help: "Current Infaltion total",
+	})
+
+	getInfaltionValidator = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "Infaltion_Validator",
+		Help: "Current Infaltion validator",
+	})
+
+	getMaxRetransmitSlot = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "Max_Retransmit_slot",
+		Help: "Current Retransmit Slot",
+	})
+
+	getVersion = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "Get_Version",
+			Help: "Current version",
+		},
+		[]string{"version"})
+	// getLargestAcc = prometheus.NewGauge(prometheus.GaugeOpts{
+	// 	Name: "getSupply",
+	// 	Help: "Current getSupply",
+	// })
+
+	getTokenAccountBalance = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "Get_Token_Acc_Balance",
+		Help: "Current Token Account Balance",
+	})
+
+	getEpochSceduleInfoBool = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "Get_Epoch_Schedule_Info_Bool",
+		Help: "Current Epoch Schedule info bool",
+	},
+		[]string{"warmup"})
+
+	getFirstNormalEpoch = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "Get_Get_First_Normal_Epoch",
+		Help: "Current GetFirstNormalEpoch",
+	})
+
+	getFirstNormalSlot = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "Get_Get_First_Normal_Slot",
+		Help: "Current GetFirstNormalSlot",
+	})
+
+	getLeaderScheduleSlotOffset = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "Get_Get_LeaderSchedule_SlotOffset",
+		Help: "Current GetLeaderScheduleSlotOffset",
+	})
+
+	getSlotsPerEpoch = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "Get_GetSlotsPerEpoch",
+		Help: "Current GetSlotsPerEpoch",
+	})
+
+	getSlot = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "Get_Slot_Info",
+		Help: "Current Slot",
+	})
+
+	getBalance = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "Get_Balance_Info",
+		Help: "Current Balance",
+	})
+
+	getTransactionCount = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "Get_Transection_Info",
+		Help: "Transection Info",
+	})
+
+	getSlotleader = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "Get_Slot_Leader",
+		Help: "Slot Leader",
+	},
+		[]string{"slotleader"})
+
+	getMinimumLeadger = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "Get_Min_Leadger",
+		Help: "Min Leadger",
+	})
+	getRecentContext = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "Get_Recent_Blockhash_Context",
+		Help: "Recent Context",
+	})
+	lamportsPerSignature = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "Get_Lamport_Per_Signature",
+		Help: "Lamport Per Signature",
+	})
+	getBlockHash = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "Get_Blockhash_ContextBlock",
+		Help: "Hash Context",
+	},
+		[]string{"blockhash"})
+
+	rpcRequestsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "solana_exporter_rpc_requests_total",
+			Help: "Number of RPC requests per endpoint that served them, grouped by method and result",
+		},
+		[]string{"endpoint", "method", "result"})
+
+	rpcEndpointHealthy = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "solana_exporter_rpc_endpoint_healthy",
+			Help: "Whether an RPC endpoint passed its last health check and is eligible for routing",
+		},
+		[]string{"endpoint"})
+
+	rpcEndpointSlot = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "solana_exporter_rpc_endpoint_slot",
+			Help: "Slot reported by an RPC endpoint during its last health check",
+		},
+		[]string{"endpoint"})
+
+	configLastReloadSuccessful = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "solana_exporter_config_last_reload_successful",
+			Help: "Whether the last attempt to load the config file succeeded",
+		})
+
+	configLastReloadSuccessTimestamp = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "solana_exporter_config_last_reload_success_timestamp_seconds",
+			Help: "Timestamp of the last successful config load",
+		})
+
+	pollerErrorsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "solana_exporter_poller_errors_total",
+			Help: "Number of failed readings per auxiliary poller",
+		},
+		[]string{"poller"})
+
+	pubsubConnected = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "solana_exporter_pubsub_connected",
+			Help: "Whether the PubSub WebSocket connection is established",
+		})
 )
 
 var firstAvailableBlock = prometheus.NewGauge(prometheus.GaugeOpts{
@@ -76,12 +343,57 @@ var transactionCount = prometheus.NewGauge(prometheus.GaugeOpts{
 })
 
 func init() {
+
 	prometheus.MustRegister(totalTransactionsTotal)
 	prometheus.MustRegister(confirmedSlotHeight)
 	prometheus.MustRegister(currentEpochNumber)
 	prometheus.MustRegister(epochFirstSlot)
 	prometheus.MustRegister(epochLastSlot)
 	prometheus.MustRegister(leaderSlotsTotal)
+	prometheus.MustRegister(leaderSlotsAssigned)
+	prometheus.MustRegister(leaderSlotsProduced)
+	prometheus.MustRegister(leaderSlotsSkipped)
+	prometheus.MustRegister(leaderSlotsRemaining)
+	prometheus.MustRegister(leaderSkipRate)
+	prometheus.MustRegister(trackedNextLeaderSlot)
+	prometheus.MustRegister(trackedNextLeaderSlotSeconds)
+	prometheus.MustRegister(trackedLeaderSlotsUpcoming)
+	prometheus.MustRegister(trackedLeaderWindowsUpcoming)
+	prometheus.MustRegister(slotDuration)
+	prometheus.MustRegister(blockTimeDrift)
+	prometheus.MustRegister(epochProjectedEnd)
+	prometheus.MustRegister(commissionChangesTotal)
+	prometheus.MustRegister(probeReachable)
+	prometheus.MustRegister(probeLatency)
+	prometheus.MustRegister(getHealth)
+	prometheus.MustRegister(getFirstAvailableBlock)
+	prometheus.MustRegister(getInflationEpoch)
+	prometheus.MustRegister(getInfaltionFoundation)
+	prometheus.MustRegister(getInfaltionTotal)
+	prometheus.MustRegister(getInfaltionValidator)
+	prometheus.MustRegister(getEpochSceduleInfoBool)
+	//prometheus.MustRegister(getLargestAcc)
+	prometheus.MustRegister(getTokenAccountBalance)
+	prometheus.MustRegister(getVersion)
+	prometheus.MustRegister(getSlot)
+	prometheus.MustRegister(getBalance)
+	prometheus.MustRegister(getFirstNormalEpoch)
+	prometheus.MustRegister(getFirstNormalSlot)
+	prometheus.MustRegister(getSlotsPerEpoch)
+	prometheus.MustRegister(getLeaderScheduleSlotOffset)
+	prometheus.MustRegister(getTransactionCount)
+	prometheus.MustRegister(getSlotleader)
+	prometheus.MustRegister(getMinimumLeadger)
+	prometheus.MustRegister(getRecentContext)
+	prometheus.MustRegister(getBlockHash)
+	prometheus.MustRegister(lamportsPerSignature)
+	prometheus.MustRegister(rpcRequestsTotal)
+	prometheus.MustRegister(rpcEndpointHealthy)
+	prometheus.MustRegister(rpcEndpointSlot)
+	prometheus.MustRegister(pollerErrorsTotal)
+	prometheus.MustRegister(pubsubConnected)
+	prometheus.MustRegister(configLastReloadSuccessful)
+	prometheus.MustRegister(configLastReloadSuccessTimestamp)
 
 	prometheus.MustRegister(firstAvailableBlock)
 	prometheus.MustRegister(maxRetransmitSlot)
@@ -90,3 +402,28 @@ func init() {
 	prometheus.MustRegister(minimumLedgerSlot)
 	prometheus.MustRegister(transactionCount)
 }
+
+func observeRPCRequest(endpoint, method string, err error) {
+	result := "success"
+	if err != nil {
+		result = "error"
+	}
+	rpcRequestsTotal.With(prometheus.Labels{"endpoint": endpoint, "method": method, "result": result}).Inc()
+}
+
+func observeRPCHealth(endpoint string, healthy bool, slot int64) {
+	if healthy {
+		rpcEndpointHealthy.WithLabelValues(endpoint).Set(1)
+	} else {
+		rpcEndpointHealthy.WithLabelValues(endpoint).Set(0)
+	}
+	rpcEndpointSlot.WithLabelValues(endpoint).Set(float64(slot))
+}
+
+func observePubSubConnection(connected bool) {
+	if connected {
+		pubsubConnected.Set(1)
+	} else {
+		pubsubConnected.Set(0)
+	}
+}
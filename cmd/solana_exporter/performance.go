@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+
+	"github.com/certusone/solana_exporter/pkg/rpc"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Windows over which throughput is averaged, in performance samples of 60 seconds each. A window is only exported if
+// the node has enough samples for it.
+var performanceWindows = []struct {
+	label   string
+	samples int
+}{
+	{"1m", 1},
+	{"10m", 10},
+	{"1h", 60},
+}
+
+// performanceCollector exports throughput from the node's recent performance samples, which, unlike the transaction
+// count, do not depend on rate() across node restarts.
+type performanceCollector struct {
+	rpcClient *rpc.RPCClient
+
+	tps            *prometheus.Desc
+	nonVoteTPS     *prometheus.Desc
+	slotsPerSecond *prometheus.Desc
+}
+
+func NewPerformanceCollector(client *rpc.RPCClient) *performanceCollector {
+	return &performanceCollector{
+		rpcClient: client,
+		tps: prometheus.NewDesc(
+			"solana_transactions_per_second",
+			"Average number of transactions per second over the window",
+			[]string{"window"}, nil),
+		nonVoteTPS: prometheus.NewDesc(
+			"solana_non_vote_transactions_per_second",
+			"Average number of non-vote transactions per second over the window, if the node reports them",
+			[]string{"window"}, nil),
+		slotsPerSecond: prometheus.NewDesc(
+			"solana_slots_per_second",
+			"Average number of slots per second over the window",
+			[]string{"window"}, nil),
+	}
+}
+
+func (c *performanceCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.tps
+	ch <- c.nonVoteTPS
+	ch <- c.slotsPerSecond
+}
+
+func (c *performanceCollector) mustPerformanceMetrics(ch chan<- prometheus.Metric, samples []rpc.PerformanceSample) {
+	for _, w := range performanceWindows {
+		if len(samples) < w.samples {
+			continue
+		}
+
+		var (
+			transactions, nonVoteTransactions, slots, seconds int64
+			// Older nodes do not count non-vote transactions.
+			hasNonVote = true
+		)
+		for _, sample := range samples[:w.samples] {
+			transactions += sample.NumTransactions
+			slots += sample.NumSlots
+			seconds += sample.SamplePeriodSecs
+			if sample.NumNonVoteTransactions == nil {
+				hasNonVote = false
+			} else {
+				nonVoteTransactions += *sample.NumNonVoteTransactions
+			}
+		}
+		if seconds == 0 {
+			continue
+		}
+
+		ch <- prometheus.MustNewConstMetric(c.tps, prometheus.GaugeValue,
+			float64(transactions)/float64(seconds), w.label)
+		ch <- prometheus.MustNewConstMetric(c.slotsPerSecond, prometheus.GaugeValue,
+			float64(slots)/float64(seconds), w.label)
+		if hasNonVote {
+			ch <- prometheus.MustNewConstMetric(c.nonVoteTPS, prometheus.GaugeValue,
+				float64(nonVoteTransactions)/float64(seconds), w.label)
+		}
+	}
+}
+
+func (c *performanceCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), httpTimeout)
+	defer cancel()
+
+	samples, err := c.rpcClient.GetRecentPerformanceSamples(ctx, performanceWindows[len(performanceWindows)-1].samples)
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(c.tps, err)
+		ch <- prometheus.NewInvalidMetric(c.nonVoteTPS, err)
+		ch <- prometheus.NewInvalidMetric(c.slotsPerSecond, err)
+	} else {
+		c.mustPerformanceMetrics(ch, samples)
+	}
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/certusone/solana_exporter/pkg/rpc"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// testSamples returns n performance samples of 60 seconds, newest first, with transactions and non-vote transactions
+// per second and 2.5 slots per second. Samples at the indexes in noNonVote do not count non-vote transactions.
+func testSamples(n int, tps, nonVoteTPS int64, noNonVote ...int) []rpc.PerformanceSample {
+	samples := make([]rpc.PerformanceSample, n)
+	for i := range samples {
+		nonVote := nonVoteTPS * 60
+		samples[i] = rpc.PerformanceSample{
+			NumTransactions:        tps * 60,
+			NumNonVoteTransactions: &nonVote,
+			NumSlots:               150,
+			SamplePeriodSecs:       60,
+		}
+	}
+	for _, i := range noNonVote {
+		samples[i].NumNonVoteTransactions = nil
+	}
+	return samples
+}
+
+func TestPerformanceMetrics(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		samples []rpc.PerformanceSample
+
+		// Values by window.
+		tps, nonVoteTPS, slotsPerSecond map[string]float64
+	}{
+		{
+			name:           "no samples",
+			tps:            map[string]float64{},
+			nonVoteTPS:     map[string]float64{},
+			slotsPerSecond: map[string]float64{},
+		},
+		{
+			name:           "samples for the shorter windows",
+			samples:        testSamples(30, 3000, 600),
+			tps:            map[string]float64{"1m": 3000, "10m": 3000},
+			nonVoteTPS:     map[string]float64{"1m": 600, "10m": 600},
+			slotsPerSecond: map[string]float64{"1m": 2.5, "10m": 2.5},
+		},
+		{
+			name: "windows average the newest samples",
+			samples: append(append(testSamples(1, 4000, 1000), testSamples(9, 3000, 500)...),
+				testSamples(50, 1000, 100)...),
+			tps:            map[string]float64{"1m": 4000, "10m": 3100, "1h": (4000 + 9*3000 + 50*1000) / 60.0},
+			nonVoteTPS:     map[string]float64{"1m": 1000, "10m": 550, "1h": (1000 + 9*500 + 50*100) / 60.0},
+			slotsPerSecond: map[string]float64{"1m": 2.5, "10m": 2.5, "1h": 2.5},
+		},
+		{
+			name: "sample without non-vote transactions",
+			// The fifth newest sample comes from an older node.
+			samples:        testSamples(60, 3000, 600, 4),
+			tps:            map[string]float64{"1m": 3000, "10m": 3000, "1h": 3000},
+			nonVoteTPS:     map[string]float64{"1m": 600},
+			slotsPerSecond: map[string]float64{"1m": 2.5, "10m": 2.5, "1h": 2.5},
+		},
+		{
+			name: "empty sample periods",
+			samples: func() []rpc.PerformanceSample {
+				samples := testSamples(10, 3000, 600)
+				for i := range samples {
+					samples[i].SamplePeriodSecs = 0
+				}
+				return samples
+			}(),
+			tps:            map[string]float64{},
+			nonVoteTPS:     map[string]float64{},
+			slotsPerSecond: map[string]float64{},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewPerformanceCollector(nil)
+			ch := make(chan prometheus.Metric, 100)
+			c.mustPerformanceMetrics(ch, tt.samples)
+			close(ch)
+
+			got := map[*prometheus.Desc]map[string]float64{c.tps: {}, c.nonVoteTPS: {}, c.slotsPerSecond: {}}
+			for metric := range ch {
+				var m dto.Metric
+				if err := metric.Write(&m); err != nil {
+					t.Fatal(err)
+				}
+				got[metric.Desc()][m.GetLabel()[0].GetValue()] = m.GetGauge().GetValue()
+			}
+			for _, g := range []struct {
+				name      string
+				got, want map[string]float64
+			}{
+				{"transactions per second", got[c.tps], tt.tps},
+				{"non-vote transactions per second", got[c.nonVoteTPS], tt.nonVoteTPS},
+				{"slots per second", got[c.slotsPerSecond], tt.slotsPerSecond},
+			} {
+				if !reflect.DeepEqual(g.got, g.want) {
+					t.Errorf("%s %v, want %v", g.name, g.got, g.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,210 @@
+package main
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/certusone/solana_exporter/pkg/rpc"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/klog/v2"
+)
+
+// poller periodically takes a single auxiliary reading. Pollers run independently of each other and of WatchSlots,
+// so a method the node does not support only affects its own metrics.
+type poller struct {
+	name     string
+	interval time.Duration
+	poll     func(ctx context.Context) error
+}
+
+func (c *solanaCollector) pollers() []poller {
+	return []poller{
+		{"health", 10 * time.Second, c.pollHealth},
+		{"first_available_block", 1 * time.Minute, c.pollFirstAvailableBlock},
+		{"transaction_count", 5 * time.Second, c.pollTransactionCount},
+		{"inflation_rate", 5 * time.Minute, c.pollInflationRate},
+		{"max_retransmit_slot", 5 * time.Second, c.pollMaxRetransmitSlot},
+		{"version", 5 * time.Minute, c.pollVersion},
+		{"token_accounts_by_delegate", 1 * time.Minute, c.pollTokenAccountsByDelegate},
+		{"epoch_schedule", 1 * time.Hour, c.pollEpochSchedule},
+		{"slot", slotPacerSchedule, c.pollSlot},
+		{"slot_leader", slotPacerSchedule, c.pollSlotLeader},
+		{"minimum_ledger_slot", 1 * time.Minute, c.pollMinimumLedgerSlot},
+		{"block_time", 10 * time.Second, c.pollBlockTime},
+	}
+}
+
+// RunPollers starts all pollers in the background.
+func (c *solanaCollector) RunPollers() {
+	for _, p := range c.pollers() {
+		go p.run()
+	}
+}
+
+func (p poller) run() {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), httpTimeout)
+		err := p.poll(ctx)
+		cancel()
+
+		if err != nil {
+			pollerErrorsTotal.WithLabelValues(p.name).Inc()
+			klog.Infof("failed to poll %s, retrying in %v: %v", p.name, p.interval, err)
+		}
+
+		<-ticker.C
+	}
+}
+
+func (c *solanaCollector) pollHealth(ctx context.Context) error {
+	health, err := c.rpcClient.GetHealth(ctx)
+	klog.V(1).Infof("Health is: %v", health)
+	// A node that is behind answers getHealth with an error; report it as unhealthy rather than skipping the reading.
+	if err != nil && !rpc.IsNodeBehind(err) {
+		return err
+	}
+
+	if health == "ok" {
+		getHealth.Set(1)
+	} else {
+		getHealth.Set(0)
+	}
+	return nil
+}
+
+func (c *solanaCollector) pollFirstAvailableBlock(ctx context.Context) error {
+	firstavailableblock, err := c.rpcClient.GetFirstAvailableBlock(ctx)
+	if err != nil {
+		return err
+	}
+	klog.V(1).Infof("firstavailableblock is: %v", firstavailableblock)
+
+	getFirstAvailableBlock.Set(float64(firstavailableblock))
+	firstAvailableBlock.Set(float64(firstavailableblock))
+	return nil
+}
+
+func (c *solanaCollector) pollTransactionCount(ctx context.Context) error {
+	gettransactioncount, err := c.rpcClient.GetTransactionCount(ctx, rpc.CommitmentMax)
+	if err != nil {
+		return err
+	}
+	klog.V(1).Infof("Transection Count is: %v", gettransactioncount)
+
+	getTransactionCount.Set(float64(gettransactioncount))
+	transactionCount.Set(float64(gettransactioncount))
+	return nil
+}
+
+func (c *solanaCollector) pollInflationRate(ctx context.Context) error {
+	inflationrate, err := c.rpcClient.GetInflationRate(ctx)
+	if err != nil {
+		return err
+	}
+	klog.V(1).Infof("Infaltion Rate is: %v", inflationrate)
+
+	getInflationEpoch.Set(inflationrate.Epoch)
+	getInfaltionFoundation.Set(inflationrate.Foundation)
+	getInfaltionTotal.Set(inflationrate.Total)
+	getInfaltionValidator.Set(inflationrate.Validator)
+	return nil
+}
+
+func (c *solanaCollector) pollMaxRetransmitSlot(ctx context.Context) error {
+	retransmitslot, err := c.rpcClient.GetMaxRetransmitSlot(ctx)
+	if err != nil {
+		return err
+	}
+	klog.V(1).Infof("Retransmit Slot is: %v", retransmitslot)
+
+	getMaxRetransmitSlot.Set(float64(retransmitslot))
+	maxRetransmitSlot.Set(float64(retransmitslot))
+	return nil
+}
+
+func (c *solanaCollector) pollVersion(ctx context.Context) error {
+	getversion, err := c.rpcClient.GetVersion(ctx)
+	if err != nil {
+		return err
+	}
+	klog.V(1).Infof("Get Version value is: %v", getversion)
+
+	getVersion.Reset()
+	getVersion.With(prometheus.Labels{"version": getversion.SolanaCore}).Add(0)
+	return nil
+}
+
+// pollTokenAccountsByDelegate queries every delegate in the config and returns the last error, if any.
+func (c *solanaCollector) pollTokenAccountsByDelegate(ctx context.Context) error {
+	var lastErr error
+	for _, delegate := range c.config.Load().TokenDelegates {
+		gettokenacc, _, err := c.rpcClient.GetTokenAccountsByDelegate(ctx, rpc.CommitmentMax,
+			delegate.Pubkey, rpc.TokenAccountsFilter{Mint: delegate.Mint})
+		if err != nil {
+			klog.Infof("failed to fetch token accounts delegated to %s: %v", delegate.Pubkey, err)
+			lastErr = err
+			continue
+		}
+		klog.V(1).Infof("Get Token Account Delegate is: %v", gettokenacc)
+	}
+	return lastErr
+}
+
+func (c *solanaCollector) pollEpochSchedule(ctx context.Context) error {
+	epochschedule, err := c.rpcClient.GetEpochSchedule(ctx)
+	if err != nil {
+		return err
+	}
+	klog.V(1).Infof("Get Epoch Schedule is: %v", epochschedule)
+
+	getFirstNormalEpoch.Set(float64(epochschedule.FirstNormalEpoch))
+	getFirstNormalSlot.Set(float64(epochschedule.FirstNormalSlot))
+	getLeaderScheduleSlotOffset.Set(float64(epochschedule.LeaderScheduleSlotOffset))
+	getSlotsPerEpoch.Set(float64(epochschedule.SlotsPerEpoch))
+	getEpochSceduleInfoBool.Reset()
+	getEpochSceduleInfoBool.With(prometheus.Labels{"warmup": strconv.FormatBool(epochschedule.Warmup)}).Add(0)
+	return nil
+}
+
+func (c *solanaCollector) pollSlot(ctx context.Context) error {
+	getslot, err := c.rpcClient.GetSlot(ctx, rpc.CommitmentMax)
+	if err != nil {
+		return err
+	}
+	klog.V(2).Infof("Get Slot: %v", getslot)
+
+	getSlot.Set(float64(getslot))
+	currentSlot.Set(float64(getslot))
+	return nil
+}
+
+func (c *solanaCollector) pollSlotLeader(ctx context.Context) error {
+	getslotleader, err := c.rpcClient.GetSlotLeader(ctx, rpc.CommitmentMax)
+	if err != nil {
+		return err
+	}
+	klog.V(2).Infof("Get Slot Leader: %v", getslotleader)
+
+	// Only export the current leader; the label changes every few slots.
+	getSlotleader.Reset()
+	getSlotleader.With(prometheus.Labels{"slotleader": getslotleader}).Add(0)
+	slotLeader.Reset()
+	slotLeader.With(prometheus.Labels{"leader": getslotleader}).Add(0)
+	return nil
+}
+
+func (c *solanaCollector) pollMinimumLedgerSlot(ctx context.Context) error {
+	minimumleadgerslot, err := c.rpcClient.GetMinimumLedgerSlot(ctx)
+	if err != nil {
+		return err
+	}
+	klog.V(1).Infof("Get Minimum Leadger Slot: %v", minimumleadgerslot)
+
+	getMinimumLeadger.Set(float64(minimumleadgerslot))
+	minimumLedgerSlot.Set(float64(minimumleadgerslot))
+	return nil
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/certusone/solana_exporter/pkg/rpc"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func TestPollers(t *testing.T) {
+	server := newFakeRPC(t, func(method string, params []interface{}) (interface{}, *rpc.Error) {
+		switch method {
+		case "getFirstAvailableBlock":
+			return 100, nil
+		case "getMaxRetransmitSlot":
+			return 1234, nil
+		case "getSlot":
+			return 1200, nil
+		case "getSlotLeader":
+			return "Leader", nil
+		case "minimumLedgerSlot":
+			return 90, nil
+		case "getTransactionCount":
+			return 5000, nil
+		}
+		return nil, &rpc.Error{Code: -32601, Message: "Method not found"}
+	})
+	defer server.Close()
+
+	config, _ := newConfigStore("")
+	c := NewSolanaCollector(rpc.NewRPCClient(server.URL), config)
+	for _, poll := range []func(context.Context) error{
+		c.pollFirstAvailableBlock, c.pollMaxRetransmitSlot, c.pollSlot, c.pollSlotLeader, c.pollMinimumLedgerSlot,
+		c.pollTransactionCount,
+	} {
+		if err := poll(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, g := range []struct {
+		gauge prometheus.Gauge
+		want  float64
+	}{
+		{firstAvailableBlock, 100},
+		{maxRetransmitSlot, 1234},
+		{currentSlot, 1200},
+		{minimumLedgerSlot, 90},
+		{transactionCount, 5000},
+	} {
+		var m dto.Metric
+		if err := g.gauge.Write(&m); err != nil {
+			t.Fatal(err)
+		}
+		if got := m.GetGauge().GetValue(); got != g.want {
+			t.Errorf("%s = %v, want %v", g.gauge.Desc(), got, g.want)
+		}
+	}
+
+	var leaders []string
+	ch := make(chan prometheus.Metric, 10)
+	slotLeader.Collect(ch)
+	close(ch)
+	for metric := range ch {
+		var m dto.Metric
+		if err := metric.Write(&m); err != nil {
+			t.Fatal(err)
+		}
+		leaders = append(leaders, m.GetLabel()[0].GetValue())
+	}
+	if len(leaders) != 1 || leaders[0] != "Leader" {
+		t.Errorf("slot leaders %v, want [Leader]", leaders)
+	}
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/certusone/solana_exporter/pkg/rpc"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// Time to wait for a connection or an answer from a probed endpoint.
+	probeTimeout = 3 * time.Second
+	// Time to wait for an ICMP port unreachable message after sending a UDP probe.
+	udpProbeWait = 500 * time.Millisecond
+)
+
+// errProbeInconclusive is returned by probes that got no answer they can tell a reachable endpoint by.
+var errProbeInconclusive = errors.New("no answer")
+
+type (
+	// prober periodically checks whether the endpoints the tracked validators advertise in gossip are reachable from
+	// the exporter.
+	prober struct {
+		rpcClient *rpc.RPCClient
+		config    *configStore
+	}
+
+	probeResult struct {
+		identity  string
+		endpoint  string
+		address   string
+		reachable bool
+		// Zero if the probe cannot measure latency.
+		latency time.Duration
+	}
+)
+
+// Run probes the tracked validators every interval.
+func (p *prober) Run(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := p.probe(); err != nil {
+			pollerErrorsTotal.WithLabelValues("prober").Inc()
+			klog.Infof("failed to probe tracked validators, retrying in %v: %v", interval, err)
+		}
+		<-ticker.C
+	}
+}
+
+func (p *prober) probe() error {
+	identities := p.config.Load().TrackedIdentities
+	if len(identities) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), httpTimeout)
+	nodes, err := p.rpcClient.GetClusterNodes(ctx)
+	cancel()
+	if err != nil {
+		return err
+	}
+
+	byIdentity := make(map[string]rpc.GetClusterNodesResult, len(nodes))
+	for _, node := range nodes {
+		byIdentity[node.Pubkey] = node
+	}
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		results []probeResult
+	)
+	run := func(identity, endpoint, address string, probe func(address string) error, timed bool) {
+		if address == "" {
+			return
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			start := time.Now()
+			err := probe(address)
+			if errors.Is(err, errProbeInconclusive) {
+				klog.V(1).Infof("%s endpoint %s of %s did not answer, reachability unknown", endpoint, address, identity)
+				return
+			}
+			var latency time.Duration
+			if timed {
+				latency = time.Since(start)
+			}
+			if err != nil {
+				klog.V(1).Infof("%s endpoint %s of %s unreachable: %v", endpoint, address, identity, err)
+			}
+
+			mu.Lock()
+			results = append(results, probeResult{identity, endpoint, address, err == nil, latency})
+			mu.Unlock()
+		}()
+	}
+	for _, identity := range identities {
+		node, ok := byIdentity[identity]
+		if !ok {
+			klog.V(1).Infof("tracked validator %s is not in gossip, not probing it", identity)
+			continue
+		}
+		run(identity, "rpc", node.RPC, p.probeRPC, true)
+		run(identity, "gossip", node.Gossip, p.probeTCP, true)
+		run(identity, "tpu", node.TPU, p.probeUDP, false)
+	}
+	wg.Wait()
+
+	probeReachable.Reset()
+	probeLatency.Reset()
+	for _, r := range results {
+		var reachable float64
+		if r.reachable {
+			reachable = 1
+			if r.latency > 0 {
+				probeLatency.WithLabelValues(r.identity, r.endpoint, r.address).Set(r.latency.Seconds())
+			}
+		}
+		probeReachable.WithLabelValues(r.identity, r.endpoint, r.address).Set(reachable)
+	}
+	return nil
+}
+
+// probeRPC calls getHealth. A node that answers with an error, for example because it is behind, is reachable.
+func (p *prober) probeRPC(address string) error {
+	client := rpc.NewRPCClient("http://" + address)
+	client.Retry.MaxAttempts = 1
+
+	ctx, cancel := context.WithTimeout(context.Background(), probeTimeout)
+	defer cancel()
+
+	_, err := client.GetHealth(ctx)
+	var rpcErr *rpc.Error
+	if errors.As(err, &rpcErr) || errors.Is(err, rpc.ErrHTTPStatus) {
+		return nil
+	}
+	return err
+}
+
+// probeTCP connects to address. Validators accept TCP connections on their gossip port, which serves the IP echo
+// protocol.
+func (p *prober) probeTCP(address string) error {
+	conn, err := net.DialTimeout("tcp", address, probeTimeout)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+// probeUDP sends an empty datagram to address and waits for an ICMP port unreachable message. UDP ports do not answer
+// otherwise, so no answer is inconclusive: the port may be open or a firewall may be silently dropping packets.
+func (p *prober) probeUDP(address string) error {
+	conn, err := net.DialTimeout("udp", address, probeTimeout)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.Write(nil); err != nil {
+		return err
+	}
+	if err := conn.SetReadDeadline(time.Now().Add(udpProbeWait)); err != nil {
+		return err
+	}
+	_, err = conn.Read(make([]byte, 1))
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return errProbeInconclusive
+	}
+	return err
+}
@@ -3,7 +3,6 @@ package main
 import (
 	"context"
 	"fmt"
-	"strconv"
 	"time"
 
 	"github.com/certusone/solana_exporter/pkg/rpc"
@@ -12,538 +11,318 @@ import (
 )
 
 const (
-	slotPacerSchedule = 1 * time.Second
+	// Maximum number of slots whose blocks are requested at once while catching up.
+	maxSlotsPerRequest = 10000
+	// Minimum time between attempts to prefetch the next epoch's leader schedule.
+	prefetchInterval = 1 * time.Minute
 )
 
-var (
-	totalTransactionsTotal = prometheus.NewGauge(prometheus.GaugeOpts{
-		Name: "solana_confirmed_transactions_total",
-		Help: "Total number of transactions processed since genesis (max confirmation)",
-	})
-
-	confirmedSlotHeight = prometheus.NewGauge(prometheus.GaugeOpts{
-		Name: "solana_confirmed_slot_height",
-		Help: "Last confirmed slot height processed by watcher routine (max confirmation)",
-	})
-
-	currentEpochNumber = prometheus.NewGauge(prometheus.GaugeOpts{
-		Name: "solana_confirmed_epoch_number",
-		Help: "Current epoch (max confirmation)",
-	})
-
-	epochFirstSlot = prometheus.NewGauge(prometheus.GaugeOpts{
-		Name: "solana_confirmed_epoch_first_slot",
-		Help: "Current epoch's first slot (max confirmation)",
-	})
-
-	epochLastSlot = prometheus.NewGauge(prometheus.GaugeOpts{
-		Name: "solana_confirmed_epoch_last_slot",
-		Help: "Current epoch's last slot (max confirmation)",
-	})
-
-	leaderSlotsTotal = prometheus.NewCounterVec(
-		prometheus.CounterOpts{
-			Name: "solana_leader_slots_total",
-			Help: "Number of leader slots per leader, grouped by skip status (max confirmation)",
-		},
-		[]string{"status", "nodekey"})
-
-	getHealth = prometheus.NewGauge(prometheus.GaugeOpts{
-		Name: "solana_health",
-		Help: "Current Health",
-	})
-
-	getFirstAvailableBlock = prometheus.NewGauge(prometheus.GaugeOpts{
-		Name: "first_available_block",
-		Help: "Current First_Block",
-	})
-
-	getInflationEpoch = prometheus.NewGauge(prometheus.GaugeOpts{
-		Name: "Infaltion_Epoch",
-		Help: "Current Infaltion_Epoch",
-	})
-
-	getInfaltionFoundation = prometheus.NewGauge(prometheus.GaugeOpts{
-		Name: "Infaltion_Foundation",
-		Help: "Current Inflation foundation",
-	})
-
-	getInfaltionTotal = prometheus.NewGauge(prometheus.GaugeOpts{
-		Name: "Infaltion_Total",
-		Help: "Current Infaltion total",
-	})
-
-	getInfaltionValidator = prometheus.NewGauge(prometheus.GaugeOpts{
-		Name: "Infaltion_Validator",
-		Help: "Current Infaltion validator",
-	})
-
-	getMaxRetransmitSlot = prometheus.NewGauge(prometheus.GaugeOpts{
-		Name: "Max_Retransmit_slot",
-		Help: "Current Retransmit Slot",
-	})
-
-	getVersion = prometheus.NewCounterVec(
-		prometheus.CounterOpts{
-			Name: "Get_Version",
-			Help: "Current version",
-		},
-		[]string{"version"})
-	// getLargestAcc = prometheus.NewGauge(prometheus.GaugeOpts{
-	// 	Name: "getSupply",
-	// 	Help: "Current getSupply",
-	// })
-
-	getTokenAccountBalance = prometheus.NewGauge(prometheus.GaugeOpts{
-		Name: "Get_Token_Acc_Balance",
-		Help: "Current Token Account Balance",
-	})
-
-	getEpochSceduleInfoBool = prometheus.NewCounterVec(prometheus.CounterOpts{
-		Name: "Get_Epoch_Schedule_Info_Bool",
-		Help: "Current Epoch Schedule info bool",
-	},
-		[]string{"warmup"})
-
-	getFirstNormalEpoch = prometheus.NewGauge(prometheus.GaugeOpts{
-		Name: "Get_Get_First_Normal_Epoch",
-		Help: "Current GetFirstNormalEpoch",
-	})
-
-	getFirstNormalSlot = prometheus.NewGauge(prometheus.GaugeOpts{
-		Name: "Get_Get_First_Normal_Slot",
-		Help: "Current GetFirstNormalSlot",
-	})
-
-	getLeaderScheduleSlotOffset = prometheus.NewGauge(prometheus.GaugeOpts{
-		Name: "Get_Get_LeaderSchedule_SlotOffset",
-		Help: "Current GetLeaderScheduleSlotOffset",
-	})
-
-	getSlotsPerEpoch = prometheus.NewGauge(prometheus.GaugeOpts{
-		Name: "Get_GetSlotsPerEpoch",
-		Help: "Current GetSlotsPerEpoch",
-	})
-
-	getSlot = prometheus.NewGauge(prometheus.GaugeOpts{
-		Name: "Get_Slot_Info",
-		Help: "Current Slot",
-	})
-
-	getBalance = prometheus.NewGauge(prometheus.GaugeOpts{
-		Name: "Get_Balance_Info",
-		Help: "Current Balance",
-	})
-
-	getTransactionCount = prometheus.NewGauge(prometheus.GaugeOpts{
-		Name: "Get_Transection_Info",
-		Help: "Transection Info",
-	})
-
-	getSlotleader = prometheus.NewCounterVec(prometheus.CounterOpts{
-		Name: "Get_Slot_Leader",
-		Help: "Slot Leader",
-	},
-		[]string{"slotleader"})
-
-	getMinimumLeadger = prometheus.NewGauge(prometheus.GaugeOpts{
-		Name: "Get_Min_Leadger",
-		Help: "Min Leadger",
-	})
-	getRecentContext = prometheus.NewGauge(prometheus.GaugeOpts{
-		Name: "Get_Recent_Blockhash_Context",
-		Help: "Recent Context",
-	})
-	lamportsPerSignature = prometheus.NewGauge(prometheus.GaugeOpts{
-		Name: "Get_Lamport_Per_Signature",
-		Help: "Lamport Per Signature",
-	})
-	getBlockHash = prometheus.NewCounterVec(prometheus.CounterOpts{
-		Name: "Get_Blockhash_ContextBlock",
-		Help: "Hash Context",
-	},
-		[]string{"blockhash"})
-)
+// blocksFunc lists the blocks produced from startSlot to endSlot (inclusive).
+type blocksFunc func(ctx context.Context, commitment rpc.Commitment, startSlot, endSlot int64) ([]int64, error)
+
+// WatchSlots tracks the confirmed slot and accounts for leader slots. It only depends on getEpochInfo,
+// getEpochSchedule, getLeaderSchedule and getBlocks (getConfirmedBlocks on nodes older than 1.7); auxiliary readings
+// are taken by the pollers. If roots
+// is not nil, it runs whenever a new root is announced, and only falls back to polling while no notifications
+// arrive.
+//
+// If statePath is set, the watermark is persisted there, and slots missed while the exporter was not running are
+// backfilled on startup, using the leader schedules of the epochs they belong to.
+func (c *solanaCollector) WatchSlots(roots <-chan int64, statePath string) {
+	w := c.newSlotWatcher(statePath)
+
+	pace := slotPacerSchedule
+	if roots != nil {
+		pace = slotPacerFallback
+	}
+	ticker := time.NewTicker(pace)
 
-func init() {
-
-	prometheus.MustRegister(totalTransactionsTotal)
-	prometheus.MustRegister(confirmedSlotHeight)
-	prometheus.MustRegister(currentEpochNumber)
-	prometheus.MustRegister(epochFirstSlot)
-	prometheus.MustRegister(epochLastSlot)
-	prometheus.MustRegister(leaderSlotsTotal)
-	prometheus.MustRegister(getHealth)
-	prometheus.MustRegister(getFirstAvailableBlock)
-	prometheus.MustRegister(getInflationEpoch)
-	prometheus.MustRegister(getInfaltionFoundation)
-	prometheus.MustRegister(getInfaltionTotal)
-	prometheus.MustRegister(getInfaltionValidator)
-	prometheus.MustRegister(getEpochSceduleInfoBool)
-	//prometheus.MustRegister(getLargestAcc)
-	prometheus.MustRegister(getTokenAccountBalance)
-	prometheus.MustRegister(getVersion)
-	prometheus.MustRegister(getSlot)
-	prometheus.MustRegister(getBalance)
-	prometheus.MustRegister(getFirstNormalEpoch)
-	prometheus.MustRegister(getFirstNormalSlot)
-	prometheus.MustRegister(getSlotsPerEpoch)
-	prometheus.MustRegister(getLeaderScheduleSlotOffset)
-	prometheus.MustRegister(getTransactionCount)
-	prometheus.MustRegister(getSlotleader)
-	prometheus.MustRegister(getMinimumLeadger)
-	prometheus.MustRegister(getRecentContext)
-	prometheus.MustRegister(getBlockHash)
-	prometheus.MustRegister(lamportsPerSignature)
+	for {
+		select {
+		case <-ticker.C:
+		case _, ok := <-roots:
+			if !ok {
+				roots = nil
+				continue
+			}
+			// Roots often arrive in bursts; handle them in one go.
+			for len(roots) > 0 {
+				<-roots
+			}
+		}
 
+		w.step()
+	}
 }
 
-func (c *solanaCollector) WatchSlots() {
-	var (
-		// Current mapping of relative slot numbers to leader public keys.
-		epochSlots map[int64]string
-		// Current epoch number corresponding to epochSlots.
-		epochNumber int64
-		// Last slot number we generated ticks for.
-		watermark int64
-	)
-
-	ticker := time.NewTicker(slotPacerSchedule)
+// slotWatcher holds the state WatchSlots keeps between iterations.
+type slotWatcher struct {
+	c         *solanaCollector
+	statePath string
+
+	// Leader schedules by epoch, mapping slot indexes relative to the first slot of the epoch to leader public keys.
+	schedules     map[int64]map[int64]string
+	epochSchedule *rpc.EpochScheduleInfo
+	// Method used to list produced blocks, chosen by node version.
+	getBlocks blocksFunc
+	// Leader slot statistics of the epoch being accounted for.
+	stats *epochLeaderStats
+	state *slotState
+	// Whether the watermark needs to be checked against the oldest block the node still has, because it was
+	// restored from the state file or the node was unreachable for a while.
+	checkLedger bool
+	// Time of the next attempt to prefetch the next epoch's leader schedule.
+	nextPrefetch time.Time
+}
 
-	for {
-		<-ticker.C
+// newSlotWatcher restores the watermark from statePath, if set.
+func (c *solanaCollector) newSlotWatcher(statePath string) *slotWatcher {
+	w := &slotWatcher{
+		c:         c,
+		statePath: statePath,
+		schedules: make(map[int64]map[int64]string),
+		state:     &slotState{},
+	}
 
-		// Get current slot height and epoch info
-		ctx, cancel := context.WithTimeout(context.Background(), httpTimeout)
-		info, err := c.rpcClient.GetEpochInfo(ctx, rpc.CommitmentMax)
+	if statePath != "" {
+		s, err := loadSlotState(statePath)
 		if err != nil {
-			klog.Infof("failed to fetch info info, retrying: %v", err)
-			cancel()
-			continue
+			klog.Errorf("failed to load state from %s, starting at the current slot: %v", statePath, err)
+		} else if s.Watermark != 0 {
+			klog.Infof("resuming leader slot accounting at slot %d", s.Watermark)
+			w.state = s
+			w.checkLedger = true
 		}
-		cancel()
-
-		// Calculate first and last slot in epoch.
-		firstSlot := info.AbsoluteSlot - info.SlotIndex
-		lastSlot := firstSlot + info.SlotsInEpoch
-
-		totalTransactionsTotal.Set(float64(info.TransactionCount))
-		confirmedSlotHeight.Set(float64(info.AbsoluteSlot))
-		currentEpochNumber.Set(float64(info.Epoch))
-		epochFirstSlot.Set(float64(firstSlot))
-		epochLastSlot.Set(float64(lastSlot))
-
-		// Check whether we need to fetch a new leader schedule
-		if epochNumber != info.Epoch {
-			klog.Infof("new epoch at slot %d: %d (previous: %d)", firstSlot, info.Epoch, epochNumber)
-
-			epochSlots, err = c.fetchLeaderSlots(firstSlot)
-			if err != nil {
-				klog.Errorf("failed to request leader schedule, retrying: %v", err)
-				continue
-			}
-
-			klog.V(1).Infof("%d leader slots in epoch %d", len(epochSlots), info.Epoch)
+	}
+	return w
+}
 
-			epochNumber = info.Epoch
-			klog.V(1).Infof("we're still in epoch %d, not fetching leader schedule", info.Epoch)
+// step accounts for the slots confirmed since the previous step.
+func (w *slotWatcher) step() {
+	c := w.c
 
-			// Reset watermark to current offset on new epoch (we do not backfill slots we missed at startup)
-			watermark = info.SlotIndex
-		} else if watermark == info.SlotIndex {
-			klog.Infof("slot has not advanced at %d, skipping", info.AbsoluteSlot)
-			continue
-		}
+	// Get current slot height and epoch info
+	ctx, cancel := context.WithTimeout(context.Background(), httpTimeout)
+	info, err := c.rpcClient.GetEpochInfo(ctx, rpc.CommitmentMax)
+	cancel()
+	if err != nil {
+		klog.Infof("failed to fetch info info, retrying: %v", err)
+		w.checkLedger = w.state.Watermark != 0
+		return
+	}
 
-		klog.Infof("confirmed slot %d (offset %d, +%d), epoch %d (from slot %d to %d, %d remaining)",
-			info.AbsoluteSlot, info.SlotIndex, info.SlotIndex-watermark, info.Epoch, firstSlot, lastSlot, lastSlot-info.AbsoluteSlot)
+	// Calculate first and last slot in epoch.
+	firstSlot := info.AbsoluteSlot - info.SlotIndex
+	lastSlot := firstSlot + info.SlotsInEpoch - 1
 
-		// Get Health
+	totalTransactionsTotal.Set(float64(info.TransactionCount))
+	confirmedSlotHeight.Set(float64(info.AbsoluteSlot))
+	currentEpochNumber.Set(float64(info.Epoch))
+	epochFirstSlot.Set(float64(firstSlot))
+	epochLastSlot.Set(float64(lastSlot))
+	c.leaders.observe(info.AbsoluteSlot, time.Now())
 
+	if w.epochSchedule == nil {
 		ctx, cancel = context.WithTimeout(context.Background(), httpTimeout)
-		health, err := c.rpcClient.GetHealth(ctx)
-		klog.Infof("Health is: %v", health)
-		fmt.Println(health + "HEALth full information")
+		w.epochSchedule, err = c.rpcClient.GetEpochSchedule(ctx)
+		cancel()
 		if err != nil {
-			klog.Infof("failed to fetch info info, retrying: %v", err)
-			cancel()
-			continue
+			klog.Errorf("failed to request epoch schedule, retrying: %v", err)
+			return
 		}
+	}
 
-		cancel()
-
-		if health == "ok" {
-			getHealth.Set(1)
-		} else {
-			getHealth.Set(0)
+	if w.getBlocks == nil {
+		if w.getBlocks, err = c.blocksMethod(); err != nil {
+			klog.Errorf("failed to request node version, retrying: %v", err)
+			return
 		}
+	}
 
-		// Get First Available Block
+	if w.state.Watermark == 0 {
+		// Nothing to backfill; start at the current slot.
+		w.state.Watermark = info.AbsoluteSlot
+	}
 
+	if w.checkLedger {
 		ctx, cancel = context.WithTimeout(context.Background(), httpTimeout)
-		firstavailableblock, err := c.rpcClient.GetFirstAvailableBlock(ctx)
-		klog.Infof("firstavailableblock is: %v", firstavailableblock)
-
-		if err != nil {
-			klog.Infof("failed to fetch info info, retrying: %v", err)
-			cancel()
-			continue
-		}
-
+		firstAvailable, err := c.rpcClient.GetFirstAvailableBlock(ctx)
 		cancel()
-
-		getFirstAvailableBlock.Set(float64(firstavailableblock))
-
-		// Get Transection Count
-
-		ctx, cancel = context.WithTimeout(context.Background(), httpTimeout)
-		gettransactioncount, err := c.rpcClient.GetTransectionCount(ctx)
-		klog.Infof("Transection Count is: %v", gettransactioncount)
-		fmt.Println(health + "Transection Count information")
 		if err != nil {
-			klog.Infof("failed to fetch info info, retrying: %v", err)
-			cancel()
-			continue
+			klog.Errorf("failed to request first available block, retrying: %v", err)
+			return
 		}
-
-		cancel()
-
-		getTransactionCount.Set(float64(gettransactioncount.Result))
-
-		// Get Inflation Rate
-
-		ctx, cancel = context.WithTimeout(context.Background(), httpTimeout)
-		inflationrate, err := c.rpcClient.GetInflationRate(ctx, rpc.CommitmentMax)
-
-		if err != nil {
-			klog.Infof("failed to fetch info info, retrying: %v", err)
-			cancel()
-			continue
+		// Blocks the node no longer has would otherwise be counted as skipped.
+		if w.state.Watermark < firstAvailable {
+			klog.Warningf("slots %d to %d are no longer available, not backfilling them", w.state.Watermark, firstAvailable-1)
+			w.state.Watermark = firstAvailable
 		}
-		klog.Infof("Infaltion Rate is: %v", inflationrate)
-
-		cancel()
-
-		// Calculate first and last slot in epoch.
-		Epoch := inflationrate.Epoch
-		Foundation := inflationrate.Foundation
-		Total := inflationrate.Total
-		Validator := inflationrate.Validator
+		w.checkLedger = false
+	}
 
-		getInflationEpoch.Set(float64(Epoch))
-		getInfaltionFoundation.Set(Foundation)
-		getInfaltionTotal.Set(Total)
-		getInfaltionValidator.Set(Validator)
+	if w.state.Watermark >= info.AbsoluteSlot {
+		klog.Infof("slot has not advanced at %d, skipping", info.AbsoluteSlot)
+		return
+	}
 
-		// Get Max Retransmit Slot
+	klog.Infof("confirmed slot %d (+%d), epoch %d (from slot %d to %d, %d remaining)",
+		info.AbsoluteSlot, info.AbsoluteSlot-w.state.Watermark, info.Epoch, firstSlot, lastSlot, lastSlot-info.AbsoluteSlot)
 
-		ctx, cancel = context.WithTimeout(context.Background(), httpTimeout)
-		retransmitslot, err := c.rpcClient.GetMaxRetransmitSlot(ctx)
+	// Account for the slots since the watermark, one epoch and at most maxSlotsPerRequest slots at a time.
+	for w.state.Watermark < info.AbsoluteSlot {
+		epoch, epochFirst, epochLast := w.epochSchedule.Epoch(w.state.Watermark)
 
-		klog.Infof("Retransmit Slot is: %v", retransmitslot)
-
-		if err != nil {
-			klog.Infof("failed to fetch info info, retrying: %v", err)
-			cancel()
-			continue
+		if w.state.Watermark == epochFirst {
+			klog.Infof("new epoch at slot %d: %d", epochFirst, epoch)
 		}
 
-		getMaxRetransmitSlot.Set(float64(retransmitslot))
-
-		//Get Version
-
-		ctx, cancel = context.WithTimeout(context.Background(), httpTimeout)
-		getversion, err := c.rpcClient.GetVersion(ctx)
-		klog.Infof("Get Token Account value is: %v", getversion)
-
-		if err != nil {
-			klog.Infof("failed to fetch info info, retrying: %v", err)
-			cancel()
-			continue
+		leaders, ok := w.schedules[epoch]
+		if !ok {
+			leaders, err = c.fetchLeaderSlots(epochFirst)
+			if err != nil {
+				klog.Errorf("failed to request leader schedule for epoch %d, retrying: %v", epoch, err)
+				break
+			}
+			if len(leaders) == 0 && epoch >= info.Epoch {
+				klog.Errorf("node returned no leader schedule for current epoch %d, retrying", epoch)
+				break
+			}
+			klog.V(1).Infof("%d leader slots in epoch %d", len(leaders), epoch)
+			w.schedules[epoch] = leaders
 		}
 
-		getVersion.With(prometheus.Labels{"version": getversion.Result.SolonaCore}).Add(0)
-
-		//Get token acc by delegate
-
-		ctx, cancel = context.WithTimeout(context.Background(), httpTimeout)
-		gettokenacc, err := c.rpcClient.GetTokenAccDelegate(ctx)
-		klog.Infof("Get Token Account Delegate is: %v", gettokenacc)
-
-		if err != nil {
-			klog.Infof("failed to fetch info info, retrying: %v", err)
-			cancel()
-			continue
+		end := info.AbsoluteSlot - 1
+		if end > epochLast {
+			end = epochLast
 		}
-
-		//Get Eopch InfoSchedule
-
-		ctx, cancel = context.WithTimeout(context.Background(), httpTimeout)
-		epochschedule, err := c.rpcClient.GetEpochSchedule(ctx)
-		klog.Infof("Get Epoch Schedule is: %v", epochschedule)
-
-		if err != nil {
-			klog.Infof("failed to fetch info info, retrying: %v", err)
-			cancel()
-			continue
+		if end >= w.state.Watermark+maxSlotsPerRequest {
+			end = w.state.Watermark + maxSlotsPerRequest - 1
 		}
 
-		cancel()
-
-		getFirstNormalEpoch.Set(float64(epochschedule.Result.FirstNormalEpoch))
-		getFirstNormalSlot.Set(float64(epochschedule.Result.FirstNormalSlot))
-		getLeaderScheduleSlotOffset.Set(float64(epochschedule.Result.LeaderScheduleSlotOffset))
-		getSlotsPerEpoch.Set(float64(epochschedule.Result.SlotsPerEpoch))
-		getEpochSceduleInfoBool.With(prometheus.Labels{"warmup": strconv.FormatBool(epochschedule.Result.Warmup)}).Add(0)
-
-		//Get Slot Response
-
-		ctx, cancel = context.WithTimeout(context.Background(), httpTimeout)
-		getslot, err := c.rpcClient.GetSlot(ctx)
-		klog.Infof("Get Slot: %v", getslot)
-
-		if err != nil {
-			klog.Infof("failed to fetch info info, retrying: %v", err)
-			cancel()
-			continue
+		if len(leaders) == 0 {
+			// Nodes only know the leader schedules of recent epochs.
+			klog.Warningf("no leader schedule for epoch %d, not accounting for slots %d to %d", epoch, w.state.Watermark, end)
+		} else {
+			if w.stats == nil || w.stats.epoch != epoch {
+				if w.stats, err = c.newEpochLeaderStats(epoch, epochFirst, w.state.Watermark, leaders); err != nil {
+					klog.Errorf("%v, retrying", err)
+					break
+				}
+			}
+			if err := c.accountLeaderSlots(w.getBlocks, w.state.Watermark, end, epochFirst, leaders, w.stats); err != nil {
+				klog.Errorf("failed to request blocks at %d, retrying: %v", w.state.Watermark, err)
+				// The node may have been replaced by one running a different version.
+				w.getBlocks = nil
+				break
+			}
+			w.stats.export()
 		}
 
-		cancel()
-
-		getSlot.Set(float64(getslot.Result))
-
-		//Get Slot Leader
-
-		ctx, cancel = context.WithTimeout(context.Background(), httpTimeout)
-		getslotleader, err := c.rpcClient.GetSlotleader(ctx)
-		klog.Infof("Get Slot Leader: %v", getslotleader)
-
-		if err != nil {
-			klog.Infof("failed to fetch info info, retrying: %v", err)
-			cancel()
-			continue
+		w.state.Watermark = end + 1
+		if w.statePath != "" {
+			if err := w.state.save(w.statePath); err != nil {
+				klog.Errorf("failed to save state to %s: %v", w.statePath, err)
+			}
 		}
+	}
 
-		cancel()
-
-		getSlotleader.With(prometheus.Labels{"slotleader": getslotleader.Result}).Add(0)
-
-		// Get Recent Block Hash
-
-		// ctx, cancel = context.WithTimeout(context.Background(), httpTimeout)
-		// recentblockhash, err := c.rpcClient.GetRecentBlockHash(ctx, rpc.CommitmentMax)
-		// klog.Infof("Recent Block Hash Is: %v", recentblockhash)
-
-		// if err != nil {
-		// 	klog.Infof("failed to fetch info info, retrying: %v", err)
-		// 	cancel()
-		// 	continue
-		// }
-
-		// cancel()
-
-		// getRecentContext.Set(float64(recentblockhash.Result.ContextSlot.Slot))
-		// getBlockHash.With(prometheus.Labels{"blockhash": recentblockhash.Result.Value.Blockhash}).Add(0)
-		// lamportsPerSignature.Set(float64(recentblockhash.Result.Value.FeeCalculator.LamportsPerSignature))
-		// //Ger Minimum Leadger Slot
-
-		ctx, cancel = context.WithTimeout(context.Background(), httpTimeout)
-		minimumleadgerslot, err := c.rpcClient.GetMinimunLeadegerSlot(ctx)
-		klog.Infof("Get Minimum Leadger Slot: %v", minimumleadgerslot)
+	// Only the schedules of the epoch being accounted for, the one before it and the next one are needed.
+	watermarkEpoch, _, _ := w.epochSchedule.Epoch(w.state.Watermark)
+	for e := range w.schedules {
+		if e < watermarkEpoch-1 {
+			delete(w.schedules, e)
+		}
+	}
 
+	// Prefetch the next epoch's schedule, so that the first slots after the boundary are accounted for right away.
+	// Nodes know it well before the boundary, as soon as the leader schedule slot offset has passed.
+	if _, ok := w.schedules[info.Epoch+1]; !ok && w.state.Watermark >= firstSlot && time.Now().After(w.nextPrefetch) {
+		w.nextPrefetch = time.Now().Add(prefetchInterval)
+		leaders, err := c.fetchLeaderSlots(lastSlot + 1)
 		if err != nil {
-			klog.Infof("failed to fetch info, retrying: %v", err)
-			cancel()
-			continue
+			klog.Errorf("failed to prefetch leader schedule for epoch %d: %v", info.Epoch+1, err)
+		} else if len(leaders) > 0 {
+			klog.Infof("prefetched leader schedule for epoch %d (%d slots)", info.Epoch+1, len(leaders))
+			w.schedules[info.Epoch+1] = leaders
 		}
+	}
 
-		cancel()
-
-		getMinimumLeadger.Set(float64(minimumleadgerslot.Result))
-
-		// var myarr [4]string
-		// myarr[0] = "83astBRguLMdt2h5U1Tpdq5tjFoJ6noeGwaY3mDLVcri"
-		// myarr[1] = "vines1vzrYbzLMRdu58ou5XTby4qAqVRLmqo36NKPTg"
-		// myarr[2] = "4fYNw3dojWmQ4dXtSGE9epjRGy9pFSx62YypT7avPYvA"
-		// myarr[3] = "6H94zdiaYfRfPfKjYLjyr2VFBg6JHXygy84r3qhc3NsC"
-
-		// //Get Balance Response
+	c.trackLeaders(info, w.epochSchedule, w.schedules)
+}
 
-		// for i := 0; i <= len(myarr)-1; i++ {
-		// 	ctx, cancel = context.WithTimeout(context.Background(), httpTimeout)
-		// 	getbalance, err := c.rpcClient.GetBalance(ctx, myarr[i])
-		// 	klog.Infof("Get Balance: %v", getbalance)
+// blocksMethod returns the best method the node supports to list produced blocks.
+func (c *solanaCollector) blocksMethod() (blocksFunc, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), httpTimeout)
+	defer cancel()
 
-		// 	if err != nil {
-		// 		klog.Infof("failed to fetch info info, retrying: %v", err)
-		// 		cancel()
-		// 		continue
-		// 	}
-		// 	cancel()
+	version, err := c.rpcClient.GetVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
 
-		// 	getBalance.Set(float64(getbalance.Result.ContextSlot))
-		// 	getBalance.Set(float64(getbalance.Result.Value))
+	if version.AtLeast(1, 7) {
+		klog.Infof("using getBlocks with solana-core %s", version.SolanaCore)
+		return c.rpcClient.GetBlocks, nil
+	}
+	klog.Infof("using deprecated getConfirmedBlocks with solana-core %s", version.SolanaCore)
+	return c.rpcClient.GetConfirmedBlocks, nil
+}
 
-		// }
-		//ver, _ := strconv.ParseInt(getversion.Result, 10, 32)
+// accountLeaderSlots counts every slot from start to end (inclusive) as valid or skipped for its leader, both in
+// solana_leader_slots_total and in stats. All slots must belong to the epoch starting at epochFirst, whose schedule
+// is leaders.
+func (c *solanaCollector) accountLeaderSlots(getBlocks blocksFunc, start, end, epochFirst int64, leaders map[int64]string, stats *epochLeaderStats) error {
+	// Get list of produced blocks in the range. The result won't contain missed blocks, allowing us to figure out
+	// block production success rate.
+	ctx, cancel := context.WithTimeout(context.Background(), httpTimeout)
+	defer cancel()
 
-		// getMaxRetransmitSlot.Add(float64(retransmitslot))
+	cfm, err := getBlocks(ctx, rpc.CommitmentMax, start, end)
+	if err != nil {
+		return err
+	}
 
-		// Get list of confirmed blocks since the last request. This is totally undocumented, but the result won't
-		// contain missed blocks, allowing us to figure out block production success rate.
+	klog.V(1).Infof("confirmed blocks: %d -> %d: %v", start, end, cfm)
 
-		rangeStart := firstSlot + watermark
-		rangeEnd := firstSlot + info.SlotIndex - 1
+	produced := make(map[int64]bool, len(cfm))
+	for _, s := range cfm {
+		produced[s] = true
+	}
 
-		ctx, cancel = context.WithTimeout(context.Background(), httpTimeout)
-		cfm, err := c.rpcClient.GetConfirmedBlocks(ctx, rangeStart, rangeEnd)
-		if err != nil {
-			klog.Errorf("failed to request confirmed blocks at %d, retrying: %v", watermark, err)
-			cancel()
-			continue
+	// Figure out leaders for each block in range
+	for abs := start; abs <= end; abs++ {
+		i := abs - epochFirst
+		leader, ok := leaders[i]
+		if !ok {
+			// This cannot happen with a well-behaved node and is a programming error in either Solana or the exporter.
+			klog.Fatalf("slot %d (offset %d) missing from leader schedule of epoch starting at %d",
+				abs, i, epochFirst)
 		}
-		cancel()
-
-		klog.V(1).Infof("confirmed blocks: %d -> %d: %v", rangeStart, rangeEnd, cfm)
-
-		// Figure out leaders for each block in range
-		for i := watermark; i < info.SlotIndex; i++ {
-			leader, ok := epochSlots[i]
-			abs := firstSlot + i
-			if !ok {
-				// This cannot happen with a well-behaved node and is a programming error in either Solana or the exporter.
-				klog.Fatalf("slot %d (offset %d) missing from epoch %d leader schedule",
-					abs, i, info.Epoch)
-			}
 
-			// Check if block was included in getConfirmedBlocks output, otherwise, it was skipped.
-			var present bool
-			for _, s := range cfm {
-				if abs == s {
-					present = true
-				}
-			}
-
-			var skipped string
-			var label string
-			if present {
-				skipped = "(valid)"
-				label = "valid"
-			} else {
-				skipped = "(SKIPPED)"
-				label = "skipped"
-			}
-
-			leaderSlotsTotal.With(prometheus.Labels{"status": label, "nodekey": leader}).Add(1)
-			klog.V(1).Infof("slot %d (offset %d) with leader %s %s", abs, i, leader, skipped)
+		// Check if block was included in the blocks list, otherwise, it was skipped.
+		var skipped string
+		var label string
+		if produced[abs] {
+			skipped = "(valid)"
+			label = "valid"
+		} else {
+			skipped = "(SKIPPED)"
+			label = "skipped"
 		}
 
-		watermark = info.SlotIndex
+		leaderSlotsTotal.With(prometheus.Labels{"status": label, "nodekey": leader}).Add(1)
+		stats.record(leader, produced[abs])
+		klog.V(1).Infof("slot %d (offset %d) with leader %s %s", abs, i, leader, skipped)
 	}
+
+	return nil
 }
 
 func (c *solanaCollector) fetchLeaderSlots(epochSlot int64) (map[int64]string, error) {
-	sch, err := c.rpcClient.GetLeaderSchedule(context.Background(), epochSlot)
+	ctx, cancel := context.WithTimeout(context.Background(), httpTimeout)
+	defer cancel()
+
+	sch, err := c.rpcClient.GetLeaderSchedule(ctx, rpc.CommitmentMax, epochSlot)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get leader schedule: %w", err)
 	}
@@ -0,0 +1,215 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/certusone/solana_exporter/pkg/rpc"
+)
+
+// Ledger of the fake node: epochs of testSlotsPerEpoch slots, each led by a single leader, with every tenth slot
+// skipped.
+const testSlotsPerEpoch = 100
+
+func testLeader(epoch int64) string {
+	return fmt.Sprintf("Leader%d", epoch)
+}
+
+func testSkipped(slot int64) bool {
+	return slot%10 == 0
+}
+
+// fakeLedger answers the calls WatchSlots makes. slot is the confirmed slot returned by getEpochInfo.
+type fakeLedger struct {
+	slot           int64
+	firstAvailable int64
+}
+
+func (l *fakeLedger) respond(method string, params []interface{}) (interface{}, *rpc.Error) {
+	switch method {
+	case "getEpochInfo":
+		return map[string]int64{
+			"absoluteSlot": l.slot,
+			"epoch":        l.slot / testSlotsPerEpoch,
+			"slotIndex":    l.slot % testSlotsPerEpoch,
+			"slotsInEpoch": testSlotsPerEpoch,
+		}, nil
+	case "getEpochSchedule":
+		return map[string]int64{"slotsPerEpoch": testSlotsPerEpoch, "leaderScheduleSlotOffset": testSlotsPerEpoch}, nil
+	case "getVersion":
+		return map[string]interface{}{"solana-core": "1.7.3"}, nil
+	case "getFirstAvailableBlock":
+		return l.firstAvailable, nil
+	case "getLeaderSchedule":
+		epoch := int64(params[0].(float64)) / testSlotsPerEpoch
+		indexes := make([]int64, testSlotsPerEpoch)
+		for i := range indexes {
+			indexes[i] = int64(i)
+		}
+		return map[string][]int64{testLeader(epoch): indexes}, nil
+	case "getBlocks":
+		blocks := []int64{}
+		for slot := int64(params[0].(float64)); slot <= int64(params[1].(float64)); slot++ {
+			if !testSkipped(slot) {
+				blocks = append(blocks, slot)
+			}
+		}
+		return blocks, nil
+	case "getBlockProduction":
+		r := params[0].(map[string]interface{})["range"].(map[string]interface{})
+		first, last := int64(r["firstSlot"].(float64)), int64(r["lastSlot"].(float64))
+		var produced int64
+		for slot := first; slot <= last; slot++ {
+			if !testSkipped(slot) {
+				produced++
+			}
+		}
+		return contextValue(l.slot, map[string]interface{}{
+			"byIdentity": map[string][]int64{testLeader(first / testSlotsPerEpoch): {last - first + 1, produced}},
+			"range":      map[string]int64{"firstSlot": first, "lastSlot": last},
+		}), nil
+	}
+	return nil, &rpc.Error{Code: -32601, Message: "Method not found"}
+}
+
+func TestSlotWatcher(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		// Watermark to start from; if restore is set, it is restored from the state file.
+		watermark      int64
+		restore        bool
+		firstAvailable int64
+		// Confirmed slot at every step.
+		slots []int64
+
+		blocks        [][2]int64
+		schedules     []int64
+		wantWatermark int64
+		// Epoch of the leader stats after the last step, and the slots accounted for in it.
+		statsEpoch        int64
+		produced, skipped int64
+	}{
+		{
+			name:      "within an epoch",
+			watermark: 450,
+			slots:     []int64{470},
+			blocks:    [][2]int64{{450, 469}},
+			// The next epoch's schedule is prefetched.
+			schedules:     []int64{400, 500},
+			wantWatermark: 470,
+			statsEpoch:    4,
+			// Slots 400 to 449 are taken from getBlockProduction.
+			produced: 63,
+			skipped:  7,
+		},
+		{
+			name:      "epoch boundary",
+			watermark: 490,
+			slots:     []int64{520},
+			// The old epoch is finished with its own schedule, the new one starts at index 0.
+			blocks:        [][2]int64{{490, 499}, {500, 519}},
+			schedules:     []int64{400, 500, 600},
+			wantWatermark: 520,
+			statsEpoch:    5,
+			produced:      18,
+			skipped:       2,
+		},
+		{
+			name:      "prefetched schedule",
+			watermark: 470,
+			slots:     []int64{480, 520},
+			blocks:    [][2]int64{{470, 479}, {480, 499}, {500, 519}},
+			// Epoch 5's schedule is only requested once, and epoch 6's prefetch waits for prefetchInterval.
+			schedules:     []int64{400, 500},
+			wantWatermark: 520,
+			statsEpoch:    5,
+			produced:      18,
+			skipped:       2,
+		},
+		{
+			name:           "restored watermark below first available block",
+			watermark:      150,
+			restore:        true,
+			firstAvailable: 430,
+			slots:          []int64{470},
+			blocks:         [][2]int64{{430, 469}},
+			schedules:      []int64{400, 500},
+			wantWatermark:  470,
+			statsEpoch:     4,
+			produced:       63,
+			skipped:        7,
+		},
+		{
+			name:           "restored watermark",
+			watermark:      350,
+			restore:        true,
+			firstAvailable: 100,
+			slots:          []int64{420},
+			blocks:         [][2]int64{{350, 399}, {400, 419}},
+			schedules:      []int64{300, 400, 500},
+			wantWatermark:  420,
+			statsEpoch:     4,
+			produced:       18,
+			skipped:        2,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ledger := &fakeLedger{firstAvailable: tt.firstAvailable}
+			server := newFakeRPC(t, ledger.respond)
+			defer server.Close()
+
+			config, _ := newConfigStore("")
+			c := NewSolanaCollector(rpc.NewRPCClient(server.URL), config)
+
+			dir, err := ioutil.TempDir("", "solana_exporter")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+			statePath := filepath.Join(dir, "state.json")
+			if tt.restore {
+				if err := (&slotState{Watermark: tt.watermark}).save(statePath); err != nil {
+					t.Fatal(err)
+				}
+			}
+			w := c.newSlotWatcher(statePath)
+			if !tt.restore {
+				w.state.Watermark = tt.watermark
+			}
+
+			for _, slot := range tt.slots {
+				ledger.slot = slot
+				w.step()
+			}
+
+			if got := server.slotRanges("getBlocks"); !reflect.DeepEqual(got, tt.blocks) {
+				t.Errorf("requested blocks %v, want %v", got, tt.blocks)
+			}
+			if got := server.slotParams("getLeaderSchedule"); !reflect.DeepEqual(got, tt.schedules) {
+				t.Errorf("requested leader schedules at %v, want %v", got, tt.schedules)
+			}
+			if w.state.Watermark != tt.wantWatermark {
+				t.Errorf("watermark %d, want %d", w.state.Watermark, tt.wantWatermark)
+			}
+			if saved, err := loadSlotState(statePath); err != nil || saved.Watermark != tt.wantWatermark {
+				t.Errorf("saved watermark %v (err %v), want %d", saved, err, tt.wantWatermark)
+			}
+
+			if w.stats == nil || w.stats.epoch != tt.statsEpoch {
+				t.Fatalf("got stats %+v, want stats of epoch %d", w.stats, tt.statsEpoch)
+			}
+			leader := testLeader(tt.statsEpoch)
+			if w.stats.produced[leader] != tt.produced || w.stats.skipped[leader] != tt.skipped {
+				t.Errorf("%s produced %d and skipped %d slots, want %d and %d",
+					leader, w.stats.produced[leader], w.stats.skipped[leader], tt.produced, tt.skipped)
+			}
+			if w.stats.assigned[leader] != testSlotsPerEpoch {
+				t.Errorf("%s assigned %d slots, want %d", leader, w.stats.assigned[leader], testSlotsPerEpoch)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// slotState is persisted to the -stateFile so that leader slots missed while the exporter was not running can be
+// backfilled on startup.
+type slotState struct {
+	// Next slot to account for. Zero if no slot was accounted for yet.
+	Watermark int64 `json:"watermark"`
+}
+
+// loadSlotState reads the state file at path. A missing file yields an empty state.
+func loadSlotState(path string) (*slotState, error) {
+	var state slotState
+
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &state, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+// save writes the state to path atomically, so that a crash never leaves a truncated file behind.
+func (s *slotState) save(path string) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/certusone/solana_exporter/pkg/rpc"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// collectorFunc adapts a function emitting metrics to an unchecked prometheus.Collector.
+type collectorFunc func(ch chan<- prometheus.Metric)
+
+func (f collectorFunc) Describe(chan<- *prometheus.Desc) {}
+
+func (f collectorFunc) Collect(ch chan<- prometheus.Metric) { f(ch) }
+
+func TestTokenAccByOwnerMetricsAreUnique(t *testing.T) {
+	const (
+		owner = "Owner111111111111111111111111111111111111111"
+		mintA = "MintA11111111111111111111111111111111111111"
+		mintB = "MintB11111111111111111111111111111111111111"
+	)
+	tokenAccount := func(pubkey, mint string) rpc.TokenAccount {
+		var account rpc.TokenAccount
+		account.Pubkey = pubkey
+		account.Account.Data.Parsed.Info.Mint = mint
+		return account
+	}
+
+	c := NewTokenAccByOwnerCollector(nil, nil)
+	registry := prometheus.NewPedanticRegistry()
+	registry.MustRegister(collectorFunc(func(ch chan<- prometheus.Metric) {
+		// The owner holds two accounts of mintA and is also listed with mintB.
+		c.mustTokenAccByOwnerMetrics(ch, []rpc.TokenAccount{
+			tokenAccount("AccountA1111111111111111111111111111111111111", mintA),
+			tokenAccount("AccountA2111111111111111111111111111111111111", mintA),
+		}, rpc.Context{Slot: 1}, PubkeyMint{owner, mintA})
+		c.mustTokenAccByOwnerMetrics(ch, []rpc.TokenAccount{
+			tokenAccount("AccountB1111111111111111111111111111111111111", mintB),
+		}, rpc.Context{Slot: 1}, PubkeyMint{owner, mintB})
+	}))
+
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("Gather failed: %v", err)
+	}
+	for _, family := range families {
+		if n := len(family.GetMetric()); n != 3 {
+			t.Errorf("%s: got %d series, want 3", family.GetName(), n)
+		}
+	}
+}
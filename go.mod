@@ -3,6 +3,8 @@ module github.com/certusone/solana_exporter
 go 1.13
 
 require (
+	github.com/gorilla/websocket v1.4.2
 	github.com/prometheus/client_golang v1.4.0
+	github.com/prometheus/client_model v0.2.0
 	k8s.io/klog/v2 v2.4.0
 )
@@ -0,0 +1,79 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+)
+
+type (
+	AccountInfo struct {
+		// number of lamports assigned to this account, as a u64
+		Lamports int64 `json:"lamports"`
+		// base-58 encoded Pubkey of the program this account has been assigned to
+		Owner string `json:"owner"`
+		// data associated with the account, either as encoded binary data or JSON format {<program>: <state>}, depending on encoding parameter
+		Data AccountData `json:"data"`
+		// boolean indicating if the account contains a program (and is strictly read-only)
+		Executable bool `json:"executable"`
+		// the epoch at which this account will next owe rent, as u64
+		RentEpoch int64 `json:"rentEpoch"`
+	}
+
+	// AccountData holds account data in either of the shapes the node returns it in.
+	AccountData struct {
+		// [data, encoding] for binary encodings
+		Encoded []string
+		// name of the program owning the account, for the jsonParsed encoding
+		Program string `json:"program"`
+		// program state, for the jsonParsed encoding
+		Parsed ParsedAccount `json:"parsed"`
+	}
+
+	ParsedAccount struct {
+		// account type as reported by the owning program, e.g. "account" or "initialized"
+		Type string     `json:"type"`
+		Info ParsedInfo `json:"info"`
+	}
+
+	// ParsedInfo is the union of the parsed account states the exporter knows about (token and nonce accounts).
+	ParsedInfo struct {
+		// token accounts
+		Mint            string      `json:"mint"`
+		Owner           string      `json:"owner"`
+		TokenAmount     TokenAmount `json:"tokenAmount"`
+		Delegate        string      `json:"delegate"`
+		DelegatedAmount TokenAmount `json:"delegatedAmount"`
+		State           string      `json:"state"`
+		IsNative        bool        `json:"isNative"`
+
+		// nonce accounts
+		Authority     string        `json:"authority"`
+		Blockhash     string        `json:"blockhash"`
+		FeeCalculator FeeCalculator `json:"feeCalculator"`
+	}
+)
+
+func (d *AccountData) UnmarshalJSON(b []byte) error {
+	if len(b) > 0 && b[0] == '[' {
+		return json.Unmarshal(b, &d.Encoded)
+	}
+
+	type parsed AccountData
+	return json.Unmarshal(b, (*parsed)(d))
+}
+
+// https://docs.solana.com/developing/clients/jsonrpc-api#getaccountinfo
+// The returned account is nil if it does not exist.
+func (c *RPCClient) GetAccountInfo(ctx context.Context, commitment Commitment, pubkey string, encoding Encoding) (*AccountInfo, Context, error) {
+	var info *AccountInfo
+	rctx, err := c.getContextResponse(ctx, "getAccountInfo",
+		formatParams(commitment, map[string]interface{}{"encoding": string(encoding)}, pubkey), &info)
+	return info, rctx, err
+}
+
+// NewGetAccountInfoCall prepares a getAccountInfo call for RPCClient.Batch, decoding the account into info. info is
+// left nil if the account does not exist.
+func NewGetAccountInfoCall(commitment Commitment, pubkey string, encoding Encoding, info **AccountInfo) *BatchCall {
+	return newContextBatchCall("getAccountInfo",
+		formatParams(commitment, map[string]interface{}{"encoding": string(encoding)}, pubkey), info)
+}
@@ -0,0 +1,42 @@
+package rpc
+
+import (
+	"encoding/json"
+)
+
+type (
+	AccountNotification struct {
+		Context Context
+		Value   *AccountInfo
+	}
+
+	AccountSubscription struct {
+		Subscription
+		C <-chan AccountNotification
+	}
+)
+
+// https://docs.solana.com/developing/clients/jsonrpc-api#accountsubscribe
+func (c *PubSubClient) AccountSubscribe(commitment Commitment, pubkey string, encoding Encoding) *AccountSubscription {
+	ch := make(chan AccountNotification, subscriptionBuffer)
+	sub := &subscription{
+		method: "accountSubscribe",
+		params: formatParams(commitment, map[string]interface{}{"encoding": string(encoding)}, pubkey),
+	}
+	sub.notify = func(result json.RawMessage) {
+		var info *AccountInfo
+		resp := RpcResponse{Value: &info}
+		if err := json.Unmarshal(result, &resp); err != nil {
+			sub.logDropped(err)
+			return
+		}
+		select {
+		case ch <- AccountNotification{Context: resp.Context, Value: info}:
+		default:
+			sub.logDropped(nil)
+		}
+	}
+	sub.close = func() { close(ch) }
+
+	return &AccountSubscription{Subscription: c.subscribe(sub), C: ch}
+}
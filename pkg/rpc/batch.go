@@ -0,0 +1,136 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/klog/v2"
+)
+
+type (
+	// BatchCall is a single call sent as part of a batch request.
+	BatchCall struct {
+		Method string
+		Params []interface{}
+		// Result receives the decoded result. It must be a pointer, or an *RpcResponse whose Value is one.
+		Result interface{}
+		// Err is set once the batch completed if this particular call failed.
+		Err error
+	}
+
+	batchResponse struct {
+		ID     int             `json:"id"`
+		Result json.RawMessage `json:"result"`
+		Error  *Error          `json:"error"`
+	}
+)
+
+// NewBatchCall prepares a call to method for RPCClient.Batch. Its result is decoded into result.
+func NewBatchCall(method string, params []interface{}, result interface{}) *BatchCall {
+	if params == nil {
+		params = []interface{}{}
+	}
+	return &BatchCall{Method: method, Params: params, Result: result}
+}
+
+// newContextBatchCall prepares a call to a method returning an RpcResponse, decoding its value into value.
+func newContextBatchCall(method string, params []interface{}, value interface{}) *BatchCall {
+	return NewBatchCall(method, params, &RpcResponse{Value: value})
+}
+
+// Context returns the context reported by calls to methods returning an RpcResponse.
+func (c *BatchCall) Context() Context {
+	if resp, ok := c.Result.(*RpcResponse); ok {
+		return resp.Context
+	}
+	return Context{}
+}
+
+func formatBatchRequest(calls []*BatchCall) []byte {
+	reqs := make([]rpcRequest, len(calls))
+	for i, call := range calls {
+		reqs[i] = rpcRequest{
+			Version: "2.0",
+			ID:      i,
+			Method:  call.Method,
+			Params:  call.Params,
+		}
+	}
+
+	b, err := json.Marshal(reqs)
+	if err != nil {
+		panic(err)
+	}
+
+	klog.V(2).Infof("jsonrpc batch request: %s", string(b))
+	return b
+}
+
+// Batch sends all calls in a single HTTP request and matches the responses back to them by id. The returned error
+// is only set if the batch as a whole failed; failures of individual calls are reported in their Err field. Only
+// failures of the batch as a whole are retried.
+func (c *RPCClient) Batch(ctx context.Context, calls []*BatchCall) error {
+	if len(calls) == 0 {
+		return nil
+	}
+
+	data := formatBatchRequest(calls)
+	return c.withRetry(ctx, "batch", func() error {
+		body, err := c.rpcRequest(ctx, "batch", data)
+		if err != nil {
+			return fmt.Errorf("RPC call failed: %w", err)
+		}
+		return decodeBatchResponse(calls, body)
+	})
+}
+
+// decodeBatchResponse decodes body into the results of calls.
+func decodeBatchResponse(calls []*BatchCall, body []byte) error {
+
+	klog.V(3).Infof("batch response: %v", string(body))
+
+	// Nodes reply with a single error object if they reject the batch as a whole.
+	if body = bytes.TrimSpace(body); len(body) > 0 && body[0] == '{' {
+		var resp batchResponse
+		if err := json.Unmarshal(body, &resp); err != nil {
+			return fmt.Errorf("%w body: %v", ErrDecode, err)
+		}
+		if resp.Error != nil {
+			return resp.Error
+		}
+		return fmt.Errorf("%w: unexpected non-batch response", ErrDecode)
+	}
+
+	var resps []batchResponse
+	if err := json.Unmarshal(body, &resps); err != nil {
+		return fmt.Errorf("%w body: %v", ErrDecode, err)
+	}
+
+	seen := make([]bool, len(calls))
+	for _, resp := range resps {
+		if resp.ID < 0 || resp.ID >= len(calls) {
+			klog.Warningf("ignoring batch response with unknown id %d", resp.ID)
+			continue
+		}
+		call := calls[resp.ID]
+		seen[resp.ID] = true
+
+		if resp.Error != nil {
+			call.Err = resp.Error
+			continue
+		}
+		if err := json.Unmarshal(resp.Result, call.Result); err != nil {
+			call.Err = fmt.Errorf("%w of %s result: %v", ErrDecode, call.Method, err)
+		}
+	}
+
+	for i, call := range calls {
+		if !seen[i] {
+			call.Err = fmt.Errorf("no response to %s in batch", call.Method)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,195 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+// newTestRPCServer returns a JSON-RPC server answering every call, single or batched, with respond. A nil *Error
+// returns result, anything else is sent as the error.
+func newTestRPCServer(t *testing.T, respond func(req rpcRequest) (result interface{}, err *Error)) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("failed to read request: %v", err)
+			return
+		}
+
+		answer := func(req rpcRequest) map[string]interface{} {
+			resp := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+			if result, rerr := respond(req); rerr != nil {
+				resp["error"] = rerr
+			} else {
+				resp["result"] = result
+			}
+			return resp
+		}
+
+		var resp interface{}
+		if bytes.HasPrefix(bytes.TrimSpace(body), []byte("[")) {
+			var reqs []rpcRequest
+			if err := json.Unmarshal(body, &reqs); err != nil {
+				t.Errorf("failed to decode batch request: %v", err)
+				return
+			}
+			resps := make([]interface{}, len(reqs))
+			for i, req := range reqs {
+				resps[i] = answer(req)
+			}
+			resp = resps
+		} else {
+			var req rpcRequest
+			if err := json.Unmarshal(body, &req); err != nil {
+				t.Errorf("failed to decode request: %v", err)
+				return
+			}
+			resp = answer(req)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}))
+}
+
+func TestBatch(t *testing.T) {
+	var requests int32
+	server := newTestRPCServer(t, func(req rpcRequest) (interface{}, *Error) {
+		atomic.AddInt32(&requests, 1)
+		switch req.Method {
+		case "getBalance":
+			if req.Params[0] == "missing" {
+				return nil, &Error{Code: -32602, Message: "Invalid param"}
+			}
+			return map[string]interface{}{"context": map[string]int64{"slot": 42}, "value": 1000}, nil
+		case "getStakeActivation":
+			return map[string]interface{}{"state": "active", "active": 5, "inactive": 1}, nil
+		}
+		return nil, &Error{Code: -32601, Message: "Method not found"}
+	})
+	defer server.Close()
+
+	var (
+		balance, missing int64
+		stake            StakeActivationInfo
+	)
+	calls := []*BatchCall{
+		NewGetBalanceCall(CommitmentRecent, "account", &balance),
+		NewGetBalanceCall(CommitmentRecent, "missing", &missing),
+		NewGetStakeActivationCall(CommitmentRecent, "stake", &stake),
+	}
+
+	client := NewRPCClient(server.URL)
+	if err := client.Batch(context.Background(), calls); err != nil {
+		t.Fatalf("Batch failed: %v", err)
+	}
+
+	if calls[0].Err != nil || balance != 1000 || calls[0].Context().Slot != 42 {
+		t.Errorf("getBalance: got %d at %+v (err %v), want 1000 at slot 42", balance, calls[0].Context(), calls[0].Err)
+	}
+	var rerr *Error
+	if !errors.As(calls[1].Err, &rerr) || rerr.Code != -32602 {
+		t.Errorf("getBalance of missing account: got err %v, want RPC error -32602", calls[1].Err)
+	}
+	want := StakeActivationInfo{State: "active", Active: 5, Inactive: 1}
+	if calls[2].Err != nil || stake != want {
+		t.Errorf("getStakeActivation: got %+v (err %v), want %+v", stake, calls[2].Err, want)
+	}
+	if calls[2].Context() != (Context{}) {
+		t.Errorf("getStakeActivation has context %+v, want none", calls[2].Context())
+	}
+
+	if err := client.Batch(context.Background(), nil); err != nil {
+		t.Errorf("empty Batch failed: %v", err)
+	}
+	if n := atomic.LoadInt32(&requests); n != 3 {
+		t.Errorf("server answered %d calls, want 3", n)
+	}
+}
+
+func TestDecodeBatchResponse(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		body string
+		// Expected results of the two calls, or errors.
+		want    [2]int64
+		wantErr [2]bool
+		// Whether the batch as a whole fails.
+		fails bool
+	}{
+		{
+			name: "in order",
+			body: `[{"id":0,"result":1},{"id":1,"result":2}]`,
+			want: [2]int64{1, 2},
+		},
+		{
+			name: "out of order",
+			body: `[{"id":1,"result":2},{"id":0,"result":1}]`,
+			want: [2]int64{1, 2},
+		},
+		{
+			name:    "call error",
+			body:    `[{"id":0,"result":1},{"id":1,"error":{"code":-32602,"message":"Invalid param"}}]`,
+			want:    [2]int64{1, 0},
+			wantErr: [2]bool{false, true},
+		},
+		{
+			name:    "missing response",
+			body:    `[{"id":1,"result":2}]`,
+			want:    [2]int64{0, 2},
+			wantErr: [2]bool{true, false},
+		},
+		{
+			name:    "unknown id ignored",
+			body:    `[{"id":0,"result":1},{"id":7,"result":3}]`,
+			want:    [2]int64{1, 0},
+			wantErr: [2]bool{false, true},
+		},
+		{
+			name:    "undecodable result",
+			body:    `[{"id":0,"result":"one"},{"id":1,"result":2}]`,
+			want:    [2]int64{0, 2},
+			wantErr: [2]bool{true, false},
+		},
+		{
+			name:  "batch rejected",
+			body:  `{"jsonrpc":"2.0","error":{"code":-32600,"message":"Invalid request"},"id":null}`,
+			fails: true,
+		},
+		{
+			name:  "malformed",
+			body:  `[{"id":0,`,
+			fails: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var results [2]int64
+			calls := []*BatchCall{
+				NewBatchCall("a", nil, &results[0]),
+				NewBatchCall("b", nil, &results[1]),
+			}
+
+			err := decodeBatchResponse(calls, []byte(tt.body))
+			if (err != nil) != tt.fails {
+				t.Fatalf("got err %v, want failure %v", err, tt.fails)
+			}
+			if tt.fails {
+				return
+			}
+			if results != tt.want {
+				t.Errorf("got results %v, want %v", results, tt.want)
+			}
+			for i, call := range calls {
+				if (call.Err != nil) != tt.wantErr[i] {
+					t.Errorf("call %d: got err %v, want error %v", i, call.Err, tt.wantErr[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+type (
+	BlockProduction struct {
+		// Leader slots and blocks produced, by validator identity
+		ByIdentity map[string]LeaderSlotProduction `json:"byIdentity"`
+		// Slot range the production was counted in
+		Range SlotRange `json:"range"`
+	}
+
+	LeaderSlotProduction struct {
+		LeaderSlots    int64
+		BlocksProduced int64
+	}
+
+	SlotRange struct {
+		FirstSlot int64 `json:"firstSlot"`
+		LastSlot  int64 `json:"lastSlot"`
+	}
+
+	// BlockProductionFilter restricts GetBlockProduction to a single identity and/or a slot range. A zero
+	// FirstSlot selects the current epoch, a zero LastSlot the most recent slot.
+	BlockProductionFilter struct {
+		Identity  string
+		FirstSlot int64
+		LastSlot  int64
+	}
+)
+
+// Nodes report production as [leaderSlots, blocksProduced] pairs.
+func (p *LeaderSlotProduction) UnmarshalJSON(data []byte) error {
+	var pair []int64
+	if err := json.Unmarshal(data, &pair); err != nil {
+		return err
+	}
+	if len(pair) != 2 {
+		return fmt.Errorf("expected [leaderSlots, blocksProduced], got %d elements", len(pair))
+	}
+	p.LeaderSlots, p.BlocksProduced = pair[0], pair[1]
+	return nil
+}
+
+// SkippedSlots returns the number of leader slots without a block.
+func (p LeaderSlotProduction) SkippedSlots() int64 {
+	return p.LeaderSlots - p.BlocksProduced
+}
+
+func (f BlockProductionFilter) config() map[string]interface{} {
+	config := map[string]interface{}{"identity": f.Identity}
+	if f.FirstSlot != 0 {
+		r := map[string]int64{"firstSlot": f.FirstSlot}
+		if f.LastSlot != 0 {
+			r["lastSlot"] = f.LastSlot
+		}
+		config["range"] = r
+	}
+	return config
+}
+
+// https://docs.solana.com/developing/clients/jsonrpc-api#getblockproduction
+func (c *RPCClient) GetBlockProduction(ctx context.Context, commitment Commitment, filter BlockProductionFilter) (*BlockProduction, Context, error) {
+	var production BlockProduction
+	rctx, err := c.getContextResponse(ctx, "getBlockProduction", formatParams(commitment, filter.config()), &production)
+	if err != nil {
+		return nil, Context{}, err
+	}
+
+	return &production, rctx, nil
+}
@@ -0,0 +1,62 @@
+package rpc
+
+import (
+	"context"
+)
+
+// Largest slot range nodes accept in a single getBlocks or getConfirmedBlocks request
+// (MAX_GET_CONFIRMED_BLOCKS_RANGE), and largest limit for getBlocksWithLimit.
+const maxBlockRange = 500000
+
+// https://docs.solana.com/developing/clients/jsonrpc-api#getblocks
+// Ranges larger than nodes accept are split into several requests.
+func (c *RPCClient) GetBlocks(ctx context.Context, commitment Commitment, startSlot, endSlot int64) ([]int64, error) {
+	return c.getBlockRange(ctx, "getBlocks", commitment, startSlot, endSlot)
+}
+
+// https://docs.solana.com/developing/clients/jsonrpc-api#getblockswithlimit
+// Limits larger than nodes accept are split into several requests.
+func (c *RPCClient) GetBlocksWithLimit(ctx context.Context, commitment Commitment, startSlot, limit int64) ([]int64, error) {
+	blocks := []int64{}
+	for limit > 0 {
+		n := limit
+		if n > maxBlockRange {
+			n = maxBlockRange
+		}
+
+		var chunk []int64
+		if err := c.getResponse(ctx, "getBlocksWithLimit", formatParams(commitment, nil, startSlot, n), &chunk); err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, chunk...)
+
+		// Fewer blocks than requested means there are none left.
+		if int64(len(chunk)) < n {
+			break
+		}
+		startSlot = chunk[len(chunk)-1] + 1
+		limit -= n
+	}
+
+	return blocks, nil
+}
+
+// getBlockRange calls method, which lists the blocks from startSlot to endSlot (inclusive), in chunks of at most
+// maxBlockRange slots.
+func (c *RPCClient) getBlockRange(ctx context.Context, method string, commitment Commitment, startSlot, endSlot int64) ([]int64, error) {
+	blocks := []int64{}
+	for start := startSlot; start <= endSlot; start += maxBlockRange {
+		end := start + maxBlockRange - 1
+		if end > endSlot {
+			end = endSlot
+		}
+
+		var chunk []int64
+		if err := c.getResponse(ctx, method, formatParams(commitment, nil, start, end), &chunk); err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, chunk...)
+	}
+
+	return blocks, nil
+}
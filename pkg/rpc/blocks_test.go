@@ -0,0 +1,164 @@
+package rpc
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// blockLedger is a test server whose ledger has a block at every multiple of interval up to lastBlock. It records
+// the params of every request.
+type blockLedger struct {
+	interval, lastBlock int64
+
+	mu       sync.Mutex
+	requests [][2]int64
+}
+
+func (l *blockLedger) respond(req rpcRequest) (interface{}, *Error) {
+	a, b := int64(req.Params[0].(float64)), int64(req.Params[1].(float64))
+	l.mu.Lock()
+	l.requests = append(l.requests, [2]int64{a, b})
+	l.mu.Unlock()
+
+	blocks := []int64{}
+	first := (a + l.interval - 1) / l.interval * l.interval
+	switch req.Method {
+	case "getBlocks", "getConfirmedBlocks":
+		for slot := first; slot <= b && slot <= l.lastBlock; slot += l.interval {
+			blocks = append(blocks, slot)
+		}
+	case "getBlocksWithLimit":
+		for slot := first; int64(len(blocks)) < b && slot <= l.lastBlock; slot += l.interval {
+			blocks = append(blocks, slot)
+		}
+	default:
+		return nil, &Error{Code: -32601, Message: "Method not found"}
+	}
+	return blocks, nil
+}
+
+func TestGetBlocks(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		start, end int64
+		requests   [][2]int64
+		wantBlocks int
+	}{
+		{
+			name:       "single request",
+			start:      100,
+			end:        2099,
+			requests:   [][2]int64{{100, 2099}},
+			wantBlocks: 2,
+		},
+		{
+			name:       "exactly the maximum range",
+			start:      0,
+			end:        maxBlockRange - 1,
+			requests:   [][2]int64{{0, maxBlockRange - 1}},
+			wantBlocks: 500,
+		},
+		{
+			name:  "chunked",
+			start: 1000,
+			end:   1200000,
+			requests: [][2]int64{
+				{1000, 500999},
+				{501000, 1000999},
+				{1001000, 1200000},
+			},
+			wantBlocks: 1200,
+		},
+		{
+			name:       "empty range",
+			start:      10,
+			end:        9,
+			requests:   nil,
+			wantBlocks: 0,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ledger := &blockLedger{interval: 1000, lastBlock: 1 << 40}
+			server := newTestRPCServer(t, ledger.respond)
+			defer server.Close()
+
+			blocks, err := NewRPCClient(server.URL).GetBlocks(context.Background(), "", tt.start, tt.end)
+			if err != nil {
+				t.Fatalf("GetBlocks failed: %v", err)
+			}
+			if !reflect.DeepEqual(ledger.requests, tt.requests) {
+				t.Errorf("requested ranges %v, want %v", ledger.requests, tt.requests)
+			}
+			if len(blocks) != tt.wantBlocks {
+				t.Errorf("got %d blocks, want %d", len(blocks), tt.wantBlocks)
+			}
+			for i := 1; i < len(blocks); i++ {
+				if blocks[i] <= blocks[i-1] {
+					t.Fatalf("blocks not in ascending order at %d: %d after %d", i, blocks[i], blocks[i-1])
+				}
+			}
+		})
+	}
+}
+
+func TestGetBlocksWithLimit(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		start     int64
+		limit     int64
+		lastBlock int64
+		requests  [][2]int64
+		want      int
+	}{
+		{
+			name:      "single request",
+			start:     5,
+			limit:     3,
+			lastBlock: 1 << 40,
+			requests:  [][2]int64{{5, 3}},
+			want:      3,
+		},
+		{
+			name:      "chunked",
+			start:     0,
+			limit:     maxBlockRange + 10,
+			lastBlock: 1 << 40,
+			// The second request starts right after the last block of the first one.
+			requests: [][2]int64{{0, maxBlockRange}, {maxBlockRange*2 - 1, 10}},
+			want:     maxBlockRange + 10,
+		},
+		{
+			name:      "ledger ends",
+			start:     0,
+			limit:     maxBlockRange * 2,
+			lastBlock: (maxBlockRange + 99) * 2,
+			requests:  [][2]int64{{0, maxBlockRange}, {maxBlockRange*2 - 1, maxBlockRange}},
+			want:      maxBlockRange + 100,
+		},
+		{
+			name:     "zero limit",
+			limit:    0,
+			requests: nil,
+			want:     0,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ledger := &blockLedger{interval: 2, lastBlock: tt.lastBlock}
+			server := newTestRPCServer(t, ledger.respond)
+			defer server.Close()
+
+			blocks, err := NewRPCClient(server.URL).GetBlocksWithLimit(context.Background(), "", tt.start, tt.limit)
+			if err != nil {
+				t.Fatalf("GetBlocksWithLimit failed: %v", err)
+			}
+			if !reflect.DeepEqual(ledger.requests, tt.requests) {
+				t.Errorf("requests %v, want %v", ledger.requests, tt.requests)
+			}
+			if len(blocks) != tt.want {
+				t.Errorf("got %d blocks, want %d", len(blocks), tt.want)
+			}
+		})
+	}
+}
@@ -4,9 +4,11 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"io"
+	"errors"
+	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 
 	"k8s.io/klog/v2"
 )
@@ -14,12 +16,18 @@ import (
 type (
 	RPCClient struct {
 		httpClient http.Client
-		rpcAddr    string
-	}
-
-	rpcError struct {
-		Message string `json:"message"`
-		Code    int64  `json:"id"`
+		endpoints  []*endpoint
+
+		// OnRequest, if set, is called after every HTTP request with the endpoint that served it. Endpoints are
+		// identified by scheme and host only, so that API keys are not leaked. It must be set before the client is
+		// used.
+		OnRequest func(endpoint, method string, err error)
+		// OnHealthCheck, if set, is called with the outcome of every endpoint health check. It must be set before
+		// the client is used.
+		OnHealthCheck func(endpoint string, healthy bool, slot int64)
+		// Retry controls how calls failing with a retryable error are repeated. It must be set before the client is
+		// used.
+		Retry RetryPolicy
 	}
 
 	rpcRequest struct {
@@ -29,12 +37,27 @@ type (
 		Params  []interface{} `json:"params"`
 	}
 
+	rpcResponse struct {
+		Result json.RawMessage `json:"result"`
+		Error  *Error          `json:"error"`
+	}
+
+	// Context describes the bank state a response was evaluated against.
+	Context struct {
+		Slot int64 `json:"slot"`
+	}
+
+	// RpcResponse is the envelope returned by methods that report the context their value was read at.
+	// Value is decoded into whatever it points to.
+	RpcResponse struct {
+		Context Context     `json:"context"`
+		Value   interface{} `json:"value"`
+	}
+
 	Commitment string
-)
 
-func (c Commitment) MarshalJSON() ([]byte, error) {
-	return json.Marshal(map[string]string{"commitment": string(c)})
-}
+	Encoding string
+)
 
 const (
 	// Most recent block confirmed by supermajority of the cluster as having reached maximum lockout.
@@ -47,16 +70,29 @@ const (
 	CommitmentRecent Commitment = "recent"
 )
 
-func NewRPCClient(rpcAddr string) *RPCClient {
+const (
+	EncodingBase58     Encoding = "base58"
+	EncodingBase64     Encoding = "base64"
+	EncodingJSONParsed Encoding = "jsonParsed"
+)
+
+// NewRPCClient returns a client for a pool of RPC endpoints. Calls go to the first healthy endpoint, in the order
+// given, and fail over to the next one on transport errors, 429 and 5xx responses. Calls failing on every endpoint
+// with a retryable error are retried according to DefaultRetryPolicy.
+func NewRPCClient(rpcAddrs ...string) *RPCClient {
 	c := &RPCClient{
 		httpClient: http.Client{},
-		rpcAddr:    rpcAddr,
+		Retry:      DefaultRetryPolicy,
+	}
+	names := endpointNames(rpcAddrs)
+	for i, addr := range rpcAddrs {
+		c.endpoints = append(c.endpoints, &endpoint{addr: addr, name: names[i], healthy: true})
 	}
 
 	return c
 }
 
-func formatRPCRequest(method string, params []interface{}) io.Reader {
+func formatRPCRequest(method string, params []interface{}) []byte {
 
 	r := &rpcRequest{
 		Version: "2.0",
@@ -71,26 +107,122 @@ func formatRPCRequest(method string, params []interface{}) io.Reader {
 	}
 
 	klog.V(2).Infof("jsonrpc request: %s", string(b))
-	return bytes.NewBuffer(b)
+	return b
+}
+
+// formatParams appends the configuration object most methods accept as their last parameter. The commitment and
+// any empty options are left out, so that nodes fall back to their defaults.
+func formatParams(commitment Commitment, config map[string]interface{}, params ...interface{}) []interface{} {
+	cfg := make(map[string]interface{})
+	if commitment != "" {
+		cfg["commitment"] = string(commitment)
+	}
+	for k, v := range config {
+		if v != "" {
+			cfg[k] = v
+		}
+	}
+
+	if len(cfg) > 0 {
+		params = append(params, cfg)
+	}
+	if params == nil {
+		params = []interface{}{}
+	}
+	return params
 }
 
-func (c *RPCClient) rpcRequest(ctx context.Context, data io.Reader) ([]byte, error) {
-	req, err := http.NewRequestWithContext(ctx, "POST", c.rpcAddr, data)
+// rpcRequest sends data to the preferred endpoint, failing over to the remaining ones in turn.
+func (c *RPCClient) rpcRequest(ctx context.Context, method string, data []byte) ([]byte, error) {
+	var lastErr error
+	for _, ep := range c.candidates() {
+		body, err := c.endpointRequest(ctx, ep, data)
+		if c.OnRequest != nil {
+			c.OnRequest(ep.name, method, err)
+		}
+		if err == nil {
+			return body, nil
+		}
+
+		lastErr = err
+		if ctx.Err() != nil || !shouldFailOver(err) {
+			break
+		}
+		klog.V(1).Infof("%s request to %s failed, trying next endpoint: %v", method, ep.name, err)
+	}
+
+	return nil, lastErr
+}
+
+func (c *RPCClient) endpointRequest(ctx context.Context, ep *endpoint, data []byte) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", ep.addr, bytes.NewReader(data))
 	if err != nil {
-		panic(err)
+		return nil, fmt.Errorf("invalid endpoint %s", ep.name)
 	}
 	req.Header.Set("content-type", "application/json")
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, err
+		// The error quotes the full URL.
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			urlErr.URL = ep.name
+		}
+		return nil, timeoutError(err)
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode >= http.StatusBadRequest {
+		return nil, fmt.Errorf("%s returned %w", ep.name, &HTTPStatusError{StatusCode: resp.StatusCode, Status: resp.Status})
+	}
+
 	body, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return nil, err
+		return nil, timeoutError(err)
 	}
 
 	return body, nil
 }
+
+// getResponse calls method and decodes its result into result.
+func (c *RPCClient) getResponse(ctx context.Context, method string, params []interface{}, result interface{}) error {
+	data := formatRPCRequest(method, params)
+	return c.withRetry(ctx, method, func() error {
+		body, err := c.rpcRequest(ctx, method, data)
+		if err != nil {
+			return fmt.Errorf("RPC call failed: %w", err)
+		}
+
+		return decodeResponse(method, body, result)
+	})
+}
+
+// decodeResponse decodes the result of a single call to method into result.
+func decodeResponse(method string, body []byte, result interface{}) error {
+	klog.V(3).Infof("%s response: %v", method, string(body))
+
+	var resp rpcResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return fmt.Errorf("%w body: %v", ErrDecode, err)
+	}
+
+	if resp.Error != nil {
+		return resp.Error
+	}
+
+	if err := json.Unmarshal(resp.Result, result); err != nil {
+		return fmt.Errorf("%w of %s result: %v", ErrDecode, method, err)
+	}
+
+	return nil
+}
+
+// getContextResponse calls a method returning an RpcResponse, decodes its value into value and returns its context.
+func (c *RPCClient) getContextResponse(ctx context.Context, method string, params []interface{}, value interface{}) (Context, error) {
+	resp := RpcResponse{Value: value}
+	if err := c.getResponse(ctx, method, params, &resp); err != nil {
+		return Context{}, err
+	}
+
+	return resp.Context, nil
+}
@@ -0,0 +1,30 @@
+package rpc
+
+import (
+	"context"
+)
+
+type GetClusterNodesResult struct {
+	// node public key, as base-58 encoded string
+	Pubkey string `json:"pubkey"`
+	// gossip network address for the node
+	Gossip string `json:"gossip"`
+	// TPU network address for the node
+	TPU string `json:"tpu"`
+	// JSON RPC network address for the node, empty if the JSON RPC service is not enabled
+	RPC string `json:"rpc"`
+	// software version of the node, empty if the version information is not available
+	Version string `json:"version"`
+	// unique identifier of the node's feature set
+	FeatureSet int64 `json:"featureSet"`
+}
+
+// https://docs.solana.com/developing/clients/jsonrpc-api#getclusternodes
+func (c *RPCClient) GetClusterNodes(ctx context.Context) ([]GetClusterNodesResult, error) {
+	var nodes []GetClusterNodesResult
+	if err := c.getResponse(ctx, "getClusterNodes", []interface{}{}, &nodes); err != nil {
+		return nil, err
+	}
+
+	return nodes, nil
+}
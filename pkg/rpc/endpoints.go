@@ -0,0 +1,177 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+type endpoint struct {
+	addr string
+	// Name used in logs, errors and callbacks instead of addr, see endpointNames.
+	name string
+
+	mu      sync.RWMutex
+	healthy bool
+}
+
+func (e *endpoint) isHealthy() bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.healthy
+}
+
+func (e *endpoint) setHealthy(healthy bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.healthy = healthy
+}
+
+// ParseEndpoints splits a comma-separated list of RPC URIs and checks that each one is an absolute http or https URL.
+// Spaces around the URIs are trimmed.
+func ParseEndpoints(uris string) ([]string, error) {
+	var addrs []string
+	for _, uri := range strings.Split(uris, ",") {
+		uri = strings.TrimSpace(uri)
+		if uri == "" {
+			return nil, fmt.Errorf("empty RPC URI in %q", uris)
+		}
+		u, err := url.Parse(uri)
+		if err != nil {
+			return nil, fmt.Errorf("invalid RPC URI: %w", err)
+		}
+		if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("invalid RPC URI %q: must be an http:// or https:// URL", uri)
+		}
+		addrs = append(addrs, uri)
+	}
+	return addrs, nil
+}
+
+// redactURL strips the userinfo, path and query from addr, as they often carry API keys.
+func redactURL(addr string) string {
+	u, err := url.Parse(addr)
+	if err != nil || u.Host == "" {
+		return "<invalid URL>"
+	}
+	return u.Scheme + "://" + u.Host
+}
+
+// endpointNames returns the redacted addresses of addrs. Endpoints sharing a redacted address, such as two API keys
+// of the same provider, are told apart by their position in addrs.
+func endpointNames(addrs []string) []string {
+	names := make([]string, len(addrs))
+	count := make(map[string]int, len(addrs))
+	for i, addr := range addrs {
+		names[i] = redactURL(addr)
+		count[names[i]]++
+	}
+	for i, name := range names {
+		if count[name] > 1 {
+			names[i] = fmt.Sprintf("%s#%d", name, i+1)
+		}
+	}
+	return names
+}
+
+// candidates returns the endpoints in the order they should be tried: healthy endpoints first, then the unhealthy
+// ones as a last resort. Both groups keep the configured order.
+func (c *RPCClient) candidates() []*endpoint {
+	eps := make([]*endpoint, 0, len(c.endpoints))
+	for _, ep := range c.endpoints {
+		if ep.isHealthy() {
+			eps = append(eps, ep)
+		}
+	}
+	for _, ep := range c.endpoints {
+		if !ep.isHealthy() {
+			eps = append(eps, ep)
+		}
+	}
+	return eps
+}
+
+// callEndpoint calls method on a single endpoint, bypassing failover.
+func (c *RPCClient) callEndpoint(ctx context.Context, ep *endpoint, method string, params []interface{}, result interface{}) error {
+	body, err := c.endpointRequest(ctx, ep, formatRPCRequest(method, params))
+	if c.OnRequest != nil {
+		c.OnRequest(ep.name, method, err)
+	}
+	if err != nil {
+		return fmt.Errorf("RPC call failed: %w", err)
+	}
+
+	return decodeResponse(method, body, result)
+}
+
+// WatchHealth probes every endpoint with getHealth and getSlot each interval until ctx is cancelled. Endpoints that
+// fail the check, or lag more than maxSlotLag slots behind the most advanced endpoint, are only used once all
+// healthy endpoints have failed.
+func (c *RPCClient) WatchHealth(ctx context.Context, interval time.Duration, maxSlotLag int64) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		checkCtx, cancel := context.WithTimeout(ctx, interval)
+		c.checkHealth(checkCtx, maxSlotLag)
+		cancel()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *RPCClient) checkHealth(ctx context.Context, maxSlotLag int64) {
+	var (
+		wg    sync.WaitGroup
+		ok    = make([]bool, len(c.endpoints))
+		slots = make([]int64, len(c.endpoints))
+	)
+
+	for i, ep := range c.endpoints {
+		wg.Add(1)
+		go func(i int, ep *endpoint) {
+			defer wg.Done()
+
+			var health string
+			if err := c.callEndpoint(ctx, ep, "getHealth", []interface{}{}, &health); err != nil {
+				klog.V(1).Infof("health check of %s failed: %v", ep.name, err)
+				return
+			}
+			if err := c.callEndpoint(ctx, ep, "getSlot", []interface{}{}, &slots[i]); err != nil {
+				klog.V(1).Infof("failed to fetch slot of %s: %v", ep.name, err)
+				return
+			}
+			ok[i] = health == "ok"
+		}(i, ep)
+	}
+	wg.Wait()
+
+	var maxSlot int64
+	for i := range c.endpoints {
+		if ok[i] && slots[i] > maxSlot {
+			maxSlot = slots[i]
+		}
+	}
+
+	for i, ep := range c.endpoints {
+		healthy := ok[i] && maxSlot-slots[i] <= maxSlotLag
+		if healthy != ep.isHealthy() {
+			klog.Infof("RPC endpoint %s is now healthy=%v (slot %d, most advanced endpoint at %d)",
+				ep.name, healthy, slots[i], maxSlot)
+		}
+		ep.setHealthy(healthy)
+
+		if c.OnHealthCheck != nil {
+			c.OnHealthCheck(ep.name, healthy, slots[i])
+		}
+	}
+}
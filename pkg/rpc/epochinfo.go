@@ -2,10 +2,6 @@ package rpc
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
-
-	"k8s.io/klog/v2"
 )
 
 type (
@@ -23,36 +19,14 @@ type (
 		// Total number of transactions ever (?)
 		TransactionCount int64 `json:"transactionCount"`
 	}
-
-	GetEpochInfoResponse struct {
-		Result EpochInfo `json:"result"`
-		Error  rpcError  `json:"error"`
-	}
 )
 
 // https://docs.solana.com/developing/clients/jsonrpc-api#getepochinfo
 func (c *RPCClient) GetEpochInfo(ctx context.Context, commitment Commitment) (*EpochInfo, error) {
-	body, err := c.rpcRequest(ctx, formatRPCRequest("getEpochInfo", []interface{}{commitment}))
-	
-	fmt.Println("~~Body: %w~~", body)
-	fmt.Println(body == nil)
-	if body == nil {
-		return nil, fmt.Errorf("RPC call failed: Body empty")
-	}
-
-	if err != nil {
-		return nil, fmt.Errorf("RPC call failed: %w", err)
-	}
-	klog.V(2).Infof("epoch info response: %v", string(body))
-
-	var resp GetEpochInfoResponse
-	if err = json.Unmarshal(body, &resp); err != nil {
-		return nil, fmt.Errorf("failed to decode response body: %w", err)
-	}
-
-	if resp.Error.Code != 0 {
-		return nil, fmt.Errorf("RPC error: %d %v", resp.Error.Code, resp.Error.Message)
+	var info EpochInfo
+	if err := c.getResponse(ctx, "getEpochInfo", formatParams(commitment, nil), &info); err != nil {
+		return nil, err
 	}
 
-	return &resp.Result, nil
+	return &info, nil
 }
@@ -0,0 +1,48 @@
+package rpc
+
+import (
+	"context"
+	"math/bits"
+)
+
+// Length of the first epoch when the cluster starts with warmup epochs.
+const minimumSlotsPerEpoch = 32
+
+type (
+	EpochScheduleInfo struct {
+		// the maximum number of slots in each epoch
+		SlotsPerEpoch int64 `json:"slotsPerEpoch"`
+		// the number of slots before beginning of an epoch to calculate a leader schedule for that epoch
+		LeaderScheduleSlotOffset int64 `json:"leaderScheduleSlotOffset"`
+		// whether epochs start short and grow
+		Warmup bool `json:"warmup"`
+		// first normal-length epoch, log2(slotsPerEpoch) - log2(MINIMUM_SLOTS_PER_EPOCH)
+		FirstNormalEpoch int64 `json:"firstNormalEpoch"`
+		// MINIMUM_SLOTS_PER_EPOCH * (2.pow(firstNormalEpoch) - 1)
+		FirstNormalSlot int64 `json:"firstNormalSlot"`
+	}
+)
+
+// https://docs.solana.com/developing/clients/jsonrpc-api#getepochschedule
+func (c *RPCClient) GetEpochSchedule(ctx context.Context) (*EpochScheduleInfo, error) {
+	var schedule EpochScheduleInfo
+	if err := c.getResponse(ctx, "getEpochSchedule", []interface{}{}, &schedule); err != nil {
+		return nil, err
+	}
+
+	return &schedule, nil
+}
+
+// Epoch returns the epoch containing slot, along with its first and last slot.
+func (s *EpochScheduleInfo) Epoch(slot int64) (epoch, firstSlot, lastSlot int64) {
+	if slot < s.FirstNormalSlot {
+		// Warmup epochs double in length, starting at minimumSlotsPerEpoch.
+		epoch = int64(bits.Len64(uint64(slot+minimumSlotsPerEpoch))) - int64(bits.Len64(minimumSlotsPerEpoch))
+		firstSlot = minimumSlotsPerEpoch<<uint(epoch) - minimumSlotsPerEpoch
+		return epoch, firstSlot, firstSlot + minimumSlotsPerEpoch<<uint(epoch) - 1
+	}
+
+	normalEpochs := (slot - s.FirstNormalSlot) / s.SlotsPerEpoch
+	firstSlot = s.FirstNormalSlot + normalEpochs*s.SlotsPerEpoch
+	return s.FirstNormalEpoch + normalEpochs, firstSlot, firstSlot + s.SlotsPerEpoch - 1
+}
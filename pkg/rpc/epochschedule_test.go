@@ -0,0 +1,69 @@
+package rpc
+
+import (
+	"testing"
+)
+
+func TestEpochScheduleEpoch(t *testing.T) {
+	// Mainnet: no warmup, 432000 slots per epoch.
+	mainnet := &EpochScheduleInfo{SlotsPerEpoch: 432000, LeaderScheduleSlotOffset: 432000}
+	// Default warmup schedule: epochs of 32, 64, ... 4096 slots, then 8192 slots from epoch 8.
+	warmup := &EpochScheduleInfo{
+		SlotsPerEpoch:            8192,
+		LeaderScheduleSlotOffset: 8192,
+		Warmup:                   true,
+		FirstNormalEpoch:         8,
+		FirstNormalSlot:          8160,
+	}
+
+	for _, tt := range []struct {
+		name      string
+		schedule  *EpochScheduleInfo
+		slot      int64
+		epoch     int64
+		firstSlot int64
+		lastSlot  int64
+	}{
+		{"mainnet first slot", mainnet, 0, 0, 0, 431999},
+		{"mainnet last slot of epoch", mainnet, 431999, 0, 0, 431999},
+		{"mainnet next epoch", mainnet, 432000, 1, 432000, 863999},
+		{"mainnet later epoch", mainnet, 100000000, 231, 99792000, 100223999},
+		{"warmup first epoch", warmup, 0, 0, 0, 31},
+		{"warmup end of first epoch", warmup, 31, 0, 0, 31},
+		{"warmup second epoch", warmup, 32, 1, 32, 95},
+		{"warmup end of second epoch", warmup, 95, 1, 32, 95},
+		{"warmup third epoch", warmup, 96, 2, 96, 223},
+		{"last warmup epoch", warmup, 8159, 7, 4064, 8159},
+		{"first normal epoch", warmup, 8160, 8, 8160, 16351},
+		{"second normal epoch", warmup, 16352, 9, 16352, 24543},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			epoch, firstSlot, lastSlot := tt.schedule.Epoch(tt.slot)
+			if epoch != tt.epoch || firstSlot != tt.firstSlot || lastSlot != tt.lastSlot {
+				t.Errorf("Epoch(%d) = %d [%d, %d], want %d [%d, %d]",
+					tt.slot, epoch, firstSlot, lastSlot, tt.epoch, tt.firstSlot, tt.lastSlot)
+			}
+		})
+	}
+}
+
+func TestEpochScheduleEpochIsContiguous(t *testing.T) {
+	schedule := &EpochScheduleInfo{SlotsPerEpoch: 1024, Warmup: true, FirstNormalEpoch: 5, FirstNormalSlot: 992}
+
+	prevEpoch, _, prevLast := schedule.Epoch(0)
+	for slot := int64(1); slot < 5000; slot++ {
+		epoch, first, last := schedule.Epoch(slot)
+		if slot < first || slot > last {
+			t.Fatalf("slot %d outside of its epoch %d [%d, %d]", slot, epoch, first, last)
+		}
+		if slot == prevLast+1 {
+			if epoch != prevEpoch+1 || first != slot {
+				t.Fatalf("slot %d: got epoch %d starting at %d, want epoch %d starting at %d",
+					slot, epoch, first, prevEpoch+1, slot)
+			}
+		} else if epoch != prevEpoch {
+			t.Fatalf("slot %d: epoch changed from %d to %d before slot %d", slot, prevEpoch, epoch, prevLast+1)
+		}
+		prevEpoch, prevLast = epoch, last
+	}
+}
@@ -0,0 +1,91 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+)
+
+const (
+	// Returned while a node is unhealthy, typically because it is behind the cluster.
+	ErrorCodeNodeUnhealthy int64 = -32005
+)
+
+var (
+	// ErrTimeout matches requests that were aborted because their deadline expired.
+	ErrTimeout = errors.New("request timed out")
+	// ErrHTTPStatus matches responses with a non-successful HTTP status, see HTTPStatusError.
+	ErrHTTPStatus = errors.New("unexpected HTTP status")
+	// ErrDecode matches responses that could not be decoded.
+	ErrDecode = errors.New("failed to decode response")
+)
+
+type (
+	// Error is an error returned by the node in a JSON-RPC response.
+	Error struct {
+		Code    int64           `json:"code"`
+		Message string          `json:"message"`
+		Data    json.RawMessage `json:"data,omitempty"`
+	}
+
+	// HTTPStatusError is returned for responses with a non-successful HTTP status. It matches ErrHTTPStatus.
+	HTTPStatusError struct {
+		StatusCode int
+		Status     string
+	}
+)
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("RPC error: %d %v", e.Code, e.Message)
+}
+
+func (e *HTTPStatusError) Error() string {
+	return fmt.Sprintf("HTTP status %s", e.Status)
+}
+
+func (e *HTTPStatusError) Is(target error) bool {
+	return target == ErrHTTPStatus
+}
+
+// IsNodeBehind reports whether err is the error a node returns while it is behind the cluster, as opposed to the
+// node being unreachable.
+func IsNodeBehind(err error) bool {
+	var rerr *Error
+	return errors.As(err, &rerr) && rerr.Code == ErrorCodeNodeUnhealthy
+}
+
+// httpStatus returns the HTTP status code carried by err, or 0.
+func httpStatus(err error) int {
+	var serr *HTTPStatusError
+	if errors.As(err, &serr) {
+		return serr.StatusCode
+	}
+	return 0
+}
+
+// isRetryable reports whether a failed call is worth repeating after a backoff.
+func isRetryable(err error) bool {
+	switch httpStatus(err) {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	}
+	return IsNodeBehind(err)
+}
+
+// shouldFailOver reports whether a request that failed on one endpoint should be tried on the next one.
+func shouldFailOver(err error) bool {
+	status := httpStatus(err)
+	return status == 0 || status == http.StatusTooManyRequests || status >= http.StatusInternalServerError
+}
+
+// timeoutError wraps err with ErrTimeout if it was caused by an expired deadline.
+func timeoutError(err error) error {
+	var nerr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &nerr) && nerr.Timeout()) {
+		return fmt.Errorf("%w: %v", ErrTimeout, err)
+	}
+	return err
+}
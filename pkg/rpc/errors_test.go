@@ -0,0 +1,90 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestErrorClassification(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		err        error
+		nodeBehind bool
+		retryable  bool
+		httpStatus bool
+	}{
+		{
+			name:       "node behind",
+			err:        &Error{Code: ErrorCodeNodeUnhealthy, Message: "Node is behind"},
+			nodeBehind: true,
+			retryable:  true,
+		},
+		{
+			name:       "wrapped node behind",
+			err:        fmt.Errorf("getSlot: %w", &Error{Code: ErrorCodeNodeUnhealthy}),
+			nodeBehind: true,
+			retryable:  true,
+		},
+		{
+			name: "other RPC error",
+			err:  &Error{Code: -32602, Message: "Invalid params"},
+		},
+		{
+			name:       "too many requests",
+			err:        fmt.Errorf("call failed: %w", &HTTPStatusError{StatusCode: http.StatusTooManyRequests}),
+			retryable:  true,
+			httpStatus: true,
+		},
+		{
+			name:       "service unavailable",
+			err:        &HTTPStatusError{StatusCode: http.StatusServiceUnavailable},
+			retryable:  true,
+			httpStatus: true,
+		},
+		{
+			name:       "internal server error",
+			err:        &HTTPStatusError{StatusCode: http.StatusInternalServerError},
+			httpStatus: true,
+		},
+		{
+			name: "transport error",
+			err:  errors.New("connection refused"),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsNodeBehind(tt.err); got != tt.nodeBehind {
+				t.Errorf("IsNodeBehind = %v, want %v", got, tt.nodeBehind)
+			}
+			if got := isRetryable(tt.err); got != tt.retryable {
+				t.Errorf("isRetryable = %v, want %v", got, tt.retryable)
+			}
+			if got := errors.Is(tt.err, ErrHTTPStatus); got != tt.httpStatus {
+				t.Errorf("errors.Is(err, ErrHTTPStatus) = %v, want %v", got, tt.httpStatus)
+			}
+		})
+	}
+}
+
+func TestTimeoutError(t *testing.T) {
+	other := errors.New("connection refused")
+	for _, tt := range []struct {
+		err  error
+		want bool
+	}{
+		{context.DeadlineExceeded, true},
+		{fmt.Errorf("Post: %w", context.DeadlineExceeded), true},
+		{context.Canceled, false},
+		{other, false},
+	} {
+		err := timeoutError(tt.err)
+		if got := errors.Is(err, ErrTimeout); got != tt.want {
+			t.Errorf("timeoutError(%v) matches ErrTimeout: %v, want %v", tt.err, got, tt.want)
+		}
+		if !tt.want && err != tt.err {
+			t.Errorf("timeoutError(%v) = %v, want it unchanged", tt.err, err)
+		}
+	}
+}
@@ -0,0 +1,16 @@
+package rpc
+
+import (
+	"context"
+)
+
+// https://docs.solana.com/developing/clients/jsonrpc-api#getfirstavailableblock
+// Returns the slot of the lowest confirmed block that has not been purged from the ledger.
+func (c *RPCClient) GetFirstAvailableBlock(ctx context.Context) (int64, error) {
+	var slot int64
+	if err := c.getResponse(ctx, "getFirstAvailableBlock", []interface{}{}, &slot); err != nil {
+		return 0, err
+	}
+
+	return slot, nil
+}
@@ -2,36 +2,15 @@ package rpc
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
-
-	"k8s.io/klog/v2"
 )
 
-type GetHealthResponse struct {
-	// the current health of the node
-	Result string   `json:"result"`
-	Error  rpcError `json:"error"`
-}
-
-//https://docs.solana.com/developing/clients/jsonrpc-api#gethealth
-func (c *RPCClient) GetHealth(ctx context.Context, rpcAddr string) (string, error) {
-	body, err := c.rpcRequest(ctx, formatRPCRequest("getHealth", []interface{}{}), rpcAddr)
-	if err != nil {
-		klog.V(2).Infof("health response: %v", err)
-		return "", fmt.Errorf("RPC call failed: %w", err)
-	}
-
-	klog.V(2).Infof("health response: %v", string(body))
-
-	var resp GetHealthResponse
-	if err = json.Unmarshal(body, &resp); err != nil {
-		return "", fmt.Errorf("failed to decode response body: %w", err)
-	}
-
-	if resp.Error.Code != 0 {
-		return "", fmt.Errorf("RPC error: %d %v", resp.Error.Code, resp.Error.Message)
+// https://docs.solana.com/developing/clients/jsonrpc-api#gethealth
+// Returns "ok" if the node is healthy. Unhealthy nodes respond with an RPC error.
+func (c *RPCClient) GetHealth(ctx context.Context) (string, error) {
+	var health string
+	if err := c.getResponse(ctx, "getHealth", []interface{}{}, &health); err != nil {
+		return "", err
 	}
 
-	return resp.Result, nil
+	return health, nil
 }
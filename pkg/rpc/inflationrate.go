@@ -0,0 +1,28 @@
+package rpc
+
+import (
+	"context"
+)
+
+type (
+	InflationInfo struct {
+		// Total inflation
+		Total float64 `json:"total"`
+		// Inflation allocated to validators
+		Validator float64 `json:"validator"`
+		// Inflation allocated to the foundation
+		Foundation float64 `json:"foundation"`
+		// Epoch for which these values are valid
+		Epoch float64 `json:"epoch"`
+	}
+)
+
+// https://docs.solana.com/developing/clients/jsonrpc-api#getinflationrate
+func (c *RPCClient) GetInflationRate(ctx context.Context) (*InflationInfo, error) {
+	var info InflationInfo
+	if err := c.getResponse(ctx, "getInflationRate", []interface{}{}, &info); err != nil {
+		return nil, err
+	}
+
+	return &info, nil
+}
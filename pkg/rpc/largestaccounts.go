@@ -0,0 +1,30 @@
+package rpc
+
+import (
+	"context"
+)
+
+type (
+	LargestAccount struct {
+		// Number of lamports in the account
+		Lamports int64 `json:"lamports"`
+		// Base-58 encoded address of the account
+		Address string `json:"address"`
+	}
+
+	LargestAccountsFilter string
+)
+
+const (
+	LargestAccountsCirculating    LargestAccountsFilter = "circulating"
+	LargestAccountsNonCirculating LargestAccountsFilter = "nonCirculating"
+)
+
+// https://docs.solana.com/developing/clients/jsonrpc-api#getlargestaccounts
+// An empty filter returns the largest accounts regardless of their circulation status.
+func (c *RPCClient) GetLargestAccounts(ctx context.Context, commitment Commitment, filter LargestAccountsFilter) ([]LargestAccount, Context, error) {
+	var accounts []LargestAccount
+	rctx, err := c.getContextResponse(ctx, "getLargestAccounts",
+		formatParams(commitment, map[string]interface{}{"filter": string(filter)}), &accounts)
+	return accounts, rctx, err
+}
@@ -2,45 +2,19 @@ package rpc
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
-
-	"k8s.io/klog/v2"
 )
 
 type (
+	// LeaderSchedule maps leader identities to the slot indices, relative to the first slot of the epoch, they lead.
 	LeaderSchedule map[string][]int64
-
-	GetLeaderScheduleResponse struct {
-		Result LeaderSchedule `json:"result"`
-		Error  rpcError       `json:"error"`
-	}
 )
 
 // https://docs.solana.com/developing/clients/jsonrpc-api#getleaderschedule
-func (c *RPCClient) GetLeaderSchedule(ctx context.Context, epochSlot int64) (LeaderSchedule, error) {
-	body, err := c.rpcRequest(ctx, formatRPCRequest("getLeaderSchedule", []interface{}{epochSlot}))
-
-	fmt.Println("~~Body: %w~~", body)
-	fmt.Println(body == nil)
-	if body == nil {
-		return nil, fmt.Errorf("RPC call failed: Body empty")
-	}
-
-	if err != nil {
-		return nil, fmt.Errorf("RPC call failed: %w", err)
-	}
-
-	klog.V(3).Infof("getLeaderSchedule response: %v", string(body))
-
-	var resp GetLeaderScheduleResponse
-	if err = json.Unmarshal(body, &resp); err != nil {
-		return nil, fmt.Errorf("failed to decode response body: %w", err)
-	}
-
-	if resp.Error.Code != 0 {
-		return nil, fmt.Errorf("RPC error: %d %v", resp.Error.Code, resp.Error.Message)
+func (c *RPCClient) GetLeaderSchedule(ctx context.Context, commitment Commitment, epochSlot int64) (LeaderSchedule, error) {
+	var schedule LeaderSchedule
+	if err := c.getResponse(ctx, "getLeaderSchedule", formatParams(commitment, nil, epochSlot), &schedule); err != nil {
+		return nil, err
 	}
 
-	return resp.Result, nil
+	return schedule, nil
 }
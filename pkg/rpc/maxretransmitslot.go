@@ -0,0 +1,16 @@
+package rpc
+
+import (
+	"context"
+)
+
+// https://docs.solana.com/developing/clients/jsonrpc-api#getmaxretransmitslot
+// Returns the max slot seen from retransmit stage.
+func (c *RPCClient) GetMaxRetransmitSlot(ctx context.Context) (int64, error) {
+	var slot int64
+	if err := c.getResponse(ctx, "getMaxRetransmitSlot", []interface{}{}, &slot); err != nil {
+		return 0, err
+	}
+
+	return slot, nil
+}
@@ -0,0 +1,16 @@
+package rpc
+
+import (
+	"context"
+)
+
+// https://docs.solana.com/developing/clients/jsonrpc-api#minimumledgerslot
+// Returns the lowest slot that the node has information about in its ledger.
+func (c *RPCClient) GetMinimumLedgerSlot(ctx context.Context) (int64, error) {
+	var slot int64
+	if err := c.getResponse(ctx, "minimumLedgerSlot", []interface{}{}, &slot); err != nil {
+		return 0, err
+	}
+
+	return slot, nil
+}
@@ -0,0 +1,37 @@
+package rpc
+
+import (
+	"context"
+)
+
+type (
+	PerformanceSample struct {
+		// Slot in which the sample was taken
+		Slot int64 `json:"slot"`
+		// Number of transactions in the sample
+		NumTransactions int64 `json:"numTransactions"`
+		// Number of non-vote transactions in the sample, nil on nodes older than 1.15
+		NumNonVoteTransactions *int64 `json:"numNonVoteTransactions"`
+		// Number of slots in the sample
+		NumSlots int64 `json:"numSlots"`
+		// Number of seconds in the sample window
+		SamplePeriodSecs int64 `json:"samplePeriodSecs"`
+	}
+)
+
+// https://docs.solana.com/developing/clients/jsonrpc-api#getrecentperformancesamples
+// Samples are taken every 60 seconds and returned newest first. A limit of 0 returns as many as the node keeps (up to
+// 720).
+func (c *RPCClient) GetRecentPerformanceSamples(ctx context.Context, limit int) ([]PerformanceSample, error) {
+	params := []interface{}{}
+	if limit > 0 {
+		params = append(params, limit)
+	}
+
+	var samples []PerformanceSample
+	if err := c.getResponse(ctx, "getRecentPerformanceSamples", params, &samples); err != nil {
+		return nil, err
+	}
+
+	return samples, nil
+}
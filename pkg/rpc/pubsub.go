@@ -0,0 +1,349 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// Notifications buffered per subscription. Notifications arriving while the buffer is full are dropped.
+	subscriptionBuffer = 64
+
+	pubsubWriteTimeout = 10 * time.Second
+	pubsubPingInterval = 30 * time.Second
+	pubsubPongWait     = 2 * pubsubPingInterval
+
+	pubsubMinBackoff = 1 * time.Second
+	pubsubMaxBackoff = 30 * time.Second
+)
+
+type (
+	// PubSubClient is a client for the WebSocket subscription API. Subscriptions outlive the connection: Run keeps
+	// reconnecting and subscribes again to everything that has not been unsubscribed.
+	PubSubClient struct {
+		addr   string
+		dialer websocket.Dialer
+
+		// OnConnectionState, if set, is called whenever the connection is established or lost. It must be set before
+		// Run is called.
+		OnConnectionState func(connected bool)
+
+		mu     sync.Mutex
+		conn   *websocket.Conn
+		nextID int
+		subs   map[*subscription]struct{}
+		// Subscriptions by the id of their pending subscribe request.
+		pending map[int]*subscription
+		// Subscriptions by the id the server assigned to them.
+		active map[int64]*subscription
+	}
+
+	subscription struct {
+		method string
+		params []interface{}
+		// notify and close are called with the client lock held.
+		notify func(result json.RawMessage)
+		close  func()
+
+		id         int64
+		subscribed bool
+		// Backoff before the subscribe request is sent again after the server rejected it, and the pending retry.
+		backoff time.Duration
+		retry   *time.Timer
+	}
+
+	// Subscription is a handle to a subscription returned by one of the PubSubClient subscribe methods.
+	Subscription struct {
+		client *PubSubClient
+		sub    *subscription
+	}
+
+	pubsubMessage struct {
+		ID     *int            `json:"id"`
+		Result json.RawMessage `json:"result"`
+		Error  *Error          `json:"error"`
+		Method string          `json:"method"`
+		Params struct {
+			Result       json.RawMessage `json:"result"`
+			Subscription int64           `json:"subscription"`
+		} `json:"params"`
+	}
+)
+
+// NewPubSubClient returns a client for the WebSocket endpoint at addr (ws:// or wss://). Subscriptions may be made
+// before Run is called and start delivering notifications once it has connected.
+func NewPubSubClient(addr string) *PubSubClient {
+	return &PubSubClient{
+		addr:    addr,
+		dialer:  websocket.Dialer{HandshakeTimeout: pubsubWriteTimeout},
+		subs:    make(map[*subscription]struct{}),
+		pending: make(map[int]*subscription),
+		active:  make(map[int64]*subscription),
+	}
+}
+
+// Run connects to the endpoint and dispatches notifications until ctx is cancelled, reconnecting with exponential
+// backoff whenever the connection is lost.
+func (c *PubSubClient) Run(ctx context.Context) {
+	backoff := pubsubMinBackoff
+	for {
+		conn, _, err := c.dialer.DialContext(ctx, c.addr, nil)
+		if err == nil {
+			klog.Infof("connected to %s", redactURL(c.addr))
+			backoff = pubsubMinBackoff
+			err = c.serve(ctx, conn)
+		}
+		if ctx.Err() != nil {
+			return
+		}
+
+		klog.Warningf("pubsub connection to %s failed, reconnecting in %v: %v", redactURL(c.addr), backoff, err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+
+		if backoff *= 2; backoff > pubsubMaxBackoff {
+			backoff = pubsubMaxBackoff
+		}
+	}
+}
+
+// serve resubscribes on conn and reads from it until it fails.
+func (c *PubSubClient) serve(ctx context.Context, conn *websocket.Conn) error {
+	c.mu.Lock()
+	c.conn = conn
+	for sub := range c.subs {
+		if err := c.subscribeLocked(sub); err != nil {
+			c.mu.Unlock()
+			c.disconnect(conn)
+			return err
+		}
+	}
+	c.mu.Unlock()
+
+	if c.OnConnectionState != nil {
+		c.OnConnectionState(true)
+	}
+	defer c.disconnect(conn)
+
+	conn.SetReadDeadline(time.Now().Add(pubsubPongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(pubsubPongWait))
+	})
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		ticker := time.NewTicker(pubsubPingInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				conn.Close()
+				return
+			case <-done:
+				return
+			case <-ticker.C:
+				if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(pubsubWriteTimeout)); err != nil {
+					klog.V(1).Infof("failed to ping %s: %v", redactURL(c.addr), err)
+				}
+			}
+		}
+	}()
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return err
+		}
+		conn.SetReadDeadline(time.Now().Add(pubsubPongWait))
+		c.handleMessage(data)
+	}
+}
+
+// disconnect closes conn and marks all subscriptions as needing to be renewed.
+func (c *PubSubClient) disconnect(conn *websocket.Conn) {
+	conn.Close()
+
+	c.mu.Lock()
+	c.conn = nil
+	c.pending = make(map[int]*subscription)
+	c.active = make(map[int64]*subscription)
+	for sub := range c.subs {
+		sub.subscribed = false
+		sub.stopRetry()
+	}
+	c.mu.Unlock()
+
+	if c.OnConnectionState != nil {
+		c.OnConnectionState(false)
+	}
+}
+
+func (c *PubSubClient) handleMessage(data []byte) {
+	klog.V(3).Infof("pubsub message: %s", string(data))
+
+	var msg pubsubMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		klog.Warningf("failed to decode pubsub message: %v", err)
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if msg.Method != "" {
+		if sub, ok := c.active[msg.Params.Subscription]; ok {
+			sub.notify(msg.Params.Result)
+		}
+		return
+	}
+
+	// Responses to unsubscribe requests are not tracked.
+	if msg.ID == nil {
+		return
+	}
+	sub, ok := c.pending[*msg.ID]
+	if !ok {
+		return
+	}
+	delete(c.pending, *msg.ID)
+
+	if msg.Error != nil {
+		c.retryLocked(sub, msg.Error)
+		return
+	}
+	var id int64
+	if err := json.Unmarshal(msg.Result, &id); err != nil {
+		klog.Errorf("failed to decode %s result: %v", sub.method, err)
+		return
+	}
+
+	// The subscription was cancelled while the request was in flight.
+	if _, ok := c.subs[sub]; !ok {
+		c.sendLocked(sub.unsubscribeMethod(), []interface{}{id})
+		return
+	}
+	sub.id = id
+	sub.subscribed = true
+	sub.backoff = 0
+	c.active[id] = sub
+}
+
+// retryLocked sends the subscribe request of sub again after a backoff, unless the connection is lost first, in which
+// case it is renewed on reconnect.
+func (c *PubSubClient) retryLocked(sub *subscription, err error) {
+	if sub.backoff *= 2; sub.backoff < pubsubMinBackoff {
+		sub.backoff = pubsubMinBackoff
+	} else if sub.backoff > pubsubMaxBackoff {
+		sub.backoff = pubsubMaxBackoff
+	}
+	klog.Errorf("%s failed, retrying in %v: %v", sub.method, sub.backoff, err)
+
+	conn := c.conn
+	sub.retry = time.AfterFunc(sub.backoff, func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		sub.retry = nil
+		if _, ok := c.subs[sub]; !ok || c.conn != conn || sub.subscribed {
+			return
+		}
+		if err := c.subscribeLocked(sub); err != nil {
+			klog.Warningf("%s failed, retrying after reconnect: %v", sub.method, err)
+		}
+	})
+}
+
+// sendLocked sends a request and returns its id. If the write fails, the connection is closed so that Run
+// reconnects.
+func (c *PubSubClient) sendLocked(method string, params []interface{}) (int, error) {
+	id := c.nextID
+	c.nextID++
+
+	req := rpcRequest{Version: "2.0", ID: id, Method: method, Params: params}
+	klog.V(2).Infof("pubsub request: %s %v", method, params)
+
+	c.conn.SetWriteDeadline(time.Now().Add(pubsubWriteTimeout))
+	if err := c.conn.WriteJSON(req); err != nil {
+		c.conn.Close()
+		return 0, err
+	}
+	return id, nil
+}
+
+func (c *PubSubClient) subscribeLocked(sub *subscription) error {
+	id, err := c.sendLocked(sub.method, sub.params)
+	if err != nil {
+		return err
+	}
+	c.pending[id] = sub
+	return nil
+}
+
+// subscribe registers sub and subscribes to it right away if the client is connected.
+func (c *PubSubClient) subscribe(sub *subscription) Subscription {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.subs[sub] = struct{}{}
+	if c.conn != nil {
+		if err := c.subscribeLocked(sub); err != nil {
+			klog.Warningf("%s failed, retrying after reconnect: %v", sub.method, err)
+		}
+	}
+	return Subscription{client: c, sub: sub}
+}
+
+// stopRetry cancels the pending retry of the subscribe request, if any.
+func (s *subscription) stopRetry() {
+	if s.retry != nil {
+		s.retry.Stop()
+		s.retry = nil
+	}
+}
+
+func (s *subscription) unsubscribeMethod() string {
+	return strings.TrimSuffix(s.method, "Subscribe") + "Unsubscribe"
+}
+
+// logDropped logs a notification that could not be delivered, either because it failed to decode (err is set) or
+// because the subscriber's buffer is full.
+func (s *subscription) logDropped(err error) {
+	if err != nil {
+		klog.Warningf("failed to decode %s notification: %v", s.method, err)
+	} else {
+		klog.V(1).Infof("dropping %s notification, subscriber is not keeping up", s.method)
+	}
+}
+
+// Unsubscribe cancels the subscription and closes its channel.
+func (s Subscription) Unsubscribe() {
+	c := s.client
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.subs[s.sub]; !ok {
+		return
+	}
+	delete(c.subs, s.sub)
+	s.sub.stopRetry()
+
+	if s.sub.subscribed {
+		delete(c.active, s.sub.id)
+		if c.conn != nil {
+			if _, err := c.sendLocked(s.sub.unsubscribeMethod(), []interface{}{s.sub.id}); err != nil {
+				klog.Warningf("%s failed: %v", s.sub.unsubscribeMethod(), err)
+			}
+		}
+	}
+	s.sub.close()
+}
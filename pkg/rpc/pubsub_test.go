@@ -0,0 +1,285 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// testPubSubServer is a WebSocket server that hands each connection, numbered from 0, to serve.
+type testPubSubServer struct {
+	*httptest.Server
+
+	mu    sync.Mutex
+	conns int
+}
+
+func newTestPubSubServer(t *testing.T, serve func(n int, conn *testPubSubConn)) *testPubSubServer {
+	s := &testPubSubServer{}
+	upgrader := websocket.Upgrader{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("upgrade failed: %v", err)
+			return
+		}
+		defer conn.Close()
+
+		s.mu.Lock()
+		n := s.conns
+		s.conns++
+		s.mu.Unlock()
+
+		serve(n, &testPubSubConn{t: t, conn: conn})
+	}))
+	return s
+}
+
+func (s *testPubSubServer) URL() string {
+	return "ws" + strings.TrimPrefix(s.Server.URL, "http")
+}
+
+type testPubSubConn struct {
+	t    *testing.T
+	conn *websocket.Conn
+}
+
+// read returns the next request, or false once the connection is closed.
+func (c *testPubSubConn) read() (rpcRequest, bool) {
+	var req rpcRequest
+	if err := c.conn.ReadJSON(&req); err != nil {
+		return req, false
+	}
+	return req, true
+}
+
+func (c *testPubSubConn) write(msg string) {
+	if err := c.conn.WriteMessage(websocket.TextMessage, []byte(msg)); err != nil {
+		c.t.Errorf("write failed: %v", err)
+	}
+}
+
+// subscribe answers the next request, which must be a call to method, with subscription id sub.
+func (c *testPubSubConn) subscribe(method string, sub int) bool {
+	req, ok := c.read()
+	if !ok {
+		return false
+	}
+	if req.Method != method {
+		c.t.Errorf("got %s request, want %s", req.Method, method)
+		return false
+	}
+	c.write(fmt.Sprintf(`{"jsonrpc":"2.0","result":%d,"id":%d}`, sub, req.ID))
+	return true
+}
+
+func (c *testPubSubConn) notify(method string, sub int, result string) {
+	c.write(fmt.Sprintf(`{"jsonrpc":"2.0","method":%q,"params":{"result":%s,"subscription":%d}}`, method, result, sub))
+}
+
+// receive returns the next value from ch, failing the test if none arrives in time.
+func receive(t *testing.T, ch <-chan int64) int64 {
+	t.Helper()
+	select {
+	case v, ok := <-ch:
+		if !ok {
+			t.Fatal("channel closed")
+		}
+		return v
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for notification")
+		return 0
+	}
+}
+
+func TestPubSubRootSubscribe(t *testing.T) {
+	unsubscribed := make(chan []interface{}, 1)
+	server := newTestPubSubServer(t, func(n int, conn *testPubSubConn) {
+		if !conn.subscribe("rootSubscribe", 7) {
+			return
+		}
+		for _, root := range []int{100, 101} {
+			conn.notify("rootNotification", 7, fmt.Sprint(root))
+		}
+		// Notifications for unknown subscriptions are ignored.
+		conn.notify("rootNotification", 8, "999")
+		conn.notify("rootNotification", 7, "102")
+
+		for {
+			req, ok := conn.read()
+			if !ok {
+				return
+			}
+			if req.Method == "rootUnsubscribe" {
+				unsubscribed <- req.Params
+			}
+		}
+	})
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client := NewPubSubClient(server.URL())
+	sub := client.RootSubscribe()
+	go client.Run(ctx)
+
+	for _, want := range []int64{100, 101, 102} {
+		if got := receive(t, sub.C); got != want {
+			t.Errorf("got root %d, want %d", got, want)
+		}
+	}
+
+	sub.Unsubscribe()
+	select {
+	case params := <-unsubscribed:
+		if len(params) != 1 || params[0] != float64(7) {
+			t.Errorf("rootUnsubscribe params = %v, want [7]", params)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for rootUnsubscribe")
+	}
+	if _, ok := <-sub.C; ok {
+		t.Error("channel not closed after Unsubscribe")
+	}
+}
+
+func TestPubSubResubscribesAfterReconnect(t *testing.T) {
+	server := newTestPubSubServer(t, func(n int, conn *testPubSubConn) {
+		// Subscription ids are only valid on their connection.
+		sub := 10 + n
+		if !conn.subscribe("rootSubscribe", sub) {
+			return
+		}
+		conn.notify("rootNotification", sub, fmt.Sprint(200+n))
+		if n == 0 {
+			return
+		}
+		for {
+			if _, ok := conn.read(); !ok {
+				return
+			}
+		}
+	})
+	defer server.Close()
+
+	var (
+		mu     sync.Mutex
+		states []bool
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client := NewPubSubClient(server.URL())
+	client.OnConnectionState = func(connected bool) {
+		mu.Lock()
+		states = append(states, connected)
+		mu.Unlock()
+	}
+	sub := client.RootSubscribe()
+	go client.Run(ctx)
+
+	for _, want := range []int64{200, 201} {
+		if got := receive(t, sub.C); got != want {
+			t.Errorf("got root %d, want %d", got, want)
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if want := []bool{true, false, true}; fmt.Sprint(states) != fmt.Sprint(want) {
+		t.Errorf("connection states = %v, want %v", states, want)
+	}
+}
+
+func TestPubSubRetriesRejectedSubscribe(t *testing.T) {
+	server := newTestPubSubServer(t, func(n int, conn *testPubSubConn) {
+		req, ok := conn.read()
+		if !ok {
+			return
+		}
+		conn.write(fmt.Sprintf(`{"jsonrpc":"2.0","error":{"code":-32603,"message":"Internal error"},"id":%d}`, req.ID))
+
+		// The rejected request is sent again on the same connection.
+		if !conn.subscribe("rootSubscribe", 3) {
+			return
+		}
+		conn.notify("rootNotification", 3, "300")
+		for {
+			if _, ok := conn.read(); !ok {
+				return
+			}
+		}
+	})
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client := NewPubSubClient(server.URL())
+	sub := client.RootSubscribe()
+	go client.Run(ctx)
+
+	if got := receive(t, sub.C); got != 300 {
+		t.Errorf("got root %d, want 300", got)
+	}
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	if server.conns != 1 {
+		t.Errorf("client connected %d times, want 1", server.conns)
+	}
+}
+
+func TestPubSubDecodesNotifications(t *testing.T) {
+	server := newTestPubSubServer(t, func(n int, conn *testPubSubConn) {
+		if !conn.subscribe("slotSubscribe", 1) {
+			return
+		}
+		conn.notify("slotNotification", 1, `"not a slot"`)
+		conn.notify("slotNotification", 1, `{"parent":41,"root":10,"slot":42}`)
+		for {
+			if _, ok := conn.read(); !ok {
+				return
+			}
+		}
+	})
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client := NewPubSubClient(server.URL())
+	sub := client.SlotSubscribe()
+	go client.Run(ctx)
+
+	select {
+	case n := <-sub.C:
+		want := SlotNotification{Parent: 41, Root: 10, Slot: 42}
+		if n != want {
+			t.Errorf("got %+v, want %+v", n, want)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for notification")
+	}
+}
+
+func TestSubscriptionUnsubscribeMethod(t *testing.T) {
+	for _, tt := range []struct {
+		method string
+		want   string
+	}{
+		{"rootSubscribe", "rootUnsubscribe"},
+		{"slotSubscribe", "slotUnsubscribe"},
+		{"voteSubscribe", "voteUnsubscribe"},
+		{"accountSubscribe", "accountUnsubscribe"},
+	} {
+		sub := &subscription{method: tt.method}
+		if got := sub.unsubscribeMethod(); got != tt.want {
+			t.Errorf("unsubscribeMethod(%s) = %s, want %s", tt.method, got, tt.want)
+		}
+	}
+}
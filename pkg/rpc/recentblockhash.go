@@ -0,0 +1,29 @@
+package rpc
+
+import (
+	"context"
+)
+
+type (
+	FeeCalculator struct {
+		LamportsPerSignature int64 `json:"lamportsPerSignature"`
+	}
+
+	BlockhashInfo struct {
+		// a Hash as base-58 encoded string
+		Blockhash string `json:"blockhash"`
+		// the fee schedule for this block hash
+		FeeCalculator FeeCalculator `json:"feeCalculator"`
+	}
+)
+
+// https://docs.solana.com/developing/clients/jsonrpc-api#getrecentblockhash
+func (c *RPCClient) GetRecentBlockhash(ctx context.Context, commitment Commitment) (*BlockhashInfo, Context, error) {
+	var info BlockhashInfo
+	rctx, err := c.getContextResponse(ctx, "getRecentBlockhash", formatParams(commitment, nil), &info)
+	if err != nil {
+		return nil, rctx, err
+	}
+
+	return &info, rctx, nil
+}
@@ -0,0 +1,52 @@
+package rpc
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+// RetryPolicy configures how calls failing with a retryable error (HTTP 429 or 503, or a node that is behind) are
+// repeated. Backoffs grow exponentially from InitialBackoff up to MaxBackoff, with full jitter.
+type RetryPolicy struct {
+	// Total number of attempts per call, including the first one. Values below 2 disable retries.
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: 200 * time.Millisecond,
+	MaxBackoff:     2 * time.Second,
+}
+
+// withRetry runs call until it succeeds, fails with an error that is not retryable, the attempts are exhausted or
+// ctx is done.
+func (c *RPCClient) withRetry(ctx context.Context, method string, call func() error) error {
+	backoff := c.Retry.InitialBackoff
+	for attempt := 1; ; attempt++ {
+		err := call()
+		if err == nil || attempt >= c.Retry.MaxAttempts || !isRetryable(err) {
+			return err
+		}
+
+		sleep := backoff
+		if sleep > 0 {
+			sleep = time.Duration(rand.Int63n(int64(sleep)))
+		}
+		klog.V(1).Infof("%s failed (attempt %d/%d), retrying in %v: %v", method, attempt, c.Retry.MaxAttempts, sleep, err)
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(sleep):
+		}
+
+		if backoff *= 2; backoff > c.Retry.MaxBackoff {
+			backoff = c.Retry.MaxBackoff
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetry(t *testing.T) {
+	behind := &Error{Code: ErrorCodeNodeUnhealthy, Message: "Node is behind by 200 slots"}
+
+	for _, tt := range []struct {
+		name string
+		// Failures of the first calls, either an HTTP status or an RPC error. Later calls succeed.
+		statuses []int
+		errors   []*Error
+		attempts int
+		// Calls expected to reach the server.
+		wantCalls int32
+		wantErr   bool
+	}{
+		{name: "success", attempts: 3, wantCalls: 1},
+		{name: "503 retried", statuses: []int{503, 503}, attempts: 3, wantCalls: 3},
+		{name: "429 retried", statuses: []int{429}, attempts: 3, wantCalls: 2},
+		{name: "attempts exhausted", statuses: []int{503, 503, 503}, attempts: 3, wantCalls: 3, wantErr: true},
+		{name: "retries disabled", statuses: []int{503}, attempts: 1, wantCalls: 1, wantErr: true},
+		{name: "500 not retried", statuses: []int{500}, attempts: 3, wantCalls: 1, wantErr: true},
+		{name: "node behind retried", errors: []*Error{behind, behind}, attempts: 3, wantCalls: 3},
+		{
+			name:      "other RPC errors not retried",
+			errors:    []*Error{{Code: -32602, Message: "Invalid params"}},
+			attempts:  3,
+			wantCalls: 1,
+			wantErr:   true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int32
+			rpcServer := newTestRPCServer(t, func(req rpcRequest) (interface{}, *Error) {
+				n := int(atomic.LoadInt32(&calls)) - 1
+				if n < len(tt.errors) {
+					return nil, tt.errors[n]
+				}
+				return int64(42), nil
+			})
+			defer rpcServer.Close()
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := int(atomic.AddInt32(&calls, 1)) - 1
+				if n < len(tt.statuses) {
+					w.WriteHeader(tt.statuses[n])
+					return
+				}
+				rpcServer.Config.Handler.ServeHTTP(w, r)
+			}))
+			defer server.Close()
+
+			client := NewRPCClient(server.URL)
+			client.Retry = RetryPolicy{MaxAttempts: tt.attempts, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+
+			slot, err := client.GetSlot(context.Background(), "")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("got err %v, want error %v", err, tt.wantErr)
+			}
+			if err == nil && slot != 42 {
+				t.Errorf("got slot %d, want 42", slot)
+			}
+			if n := atomic.LoadInt32(&calls); n != tt.wantCalls {
+				t.Errorf("server got %d calls, want %d", n, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestRetryStopsWhenContextDone(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewRPCClient(server.URL)
+	client.Retry = RetryPolicy{MaxAttempts: 100, InitialBackoff: time.Hour, MaxBackoff: time.Hour}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := client.GetSlot(ctx, "")
+	if !errors.Is(err, ErrHTTPStatus) {
+		t.Errorf("got err %v, want the last HTTP status error", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("call returned after %v, want it to stop with the context", elapsed)
+	}
+	if n := atomic.LoadInt32(&calls); n > 2 {
+		t.Errorf("server got %d calls, want at most 2", n)
+	}
+}
@@ -0,0 +1,32 @@
+package rpc
+
+import (
+	"encoding/json"
+)
+
+type RootSubscription struct {
+	Subscription
+	// C receives the slot of every new root.
+	C <-chan int64
+}
+
+// https://docs.solana.com/developing/clients/jsonrpc-api#rootsubscribe
+func (c *PubSubClient) RootSubscribe() *RootSubscription {
+	ch := make(chan int64, subscriptionBuffer)
+	sub := &subscription{method: "rootSubscribe", params: []interface{}{}}
+	sub.notify = func(result json.RawMessage) {
+		var root int64
+		if err := json.Unmarshal(result, &root); err != nil {
+			sub.logDropped(err)
+			return
+		}
+		select {
+		case ch <- root:
+		default:
+			sub.logDropped(nil)
+		}
+	}
+	sub.close = func() { close(ch) }
+
+	return &RootSubscription{Subscription: c.subscribe(sub), C: ch}
+}
@@ -0,0 +1,16 @@
+package rpc
+
+import (
+	"context"
+)
+
+// https://docs.solana.com/developing/clients/jsonrpc-api#getslotleader
+// Returns the identity of the current slot leader.
+func (c *RPCClient) GetSlotLeader(ctx context.Context, commitment Commitment) (string, error) {
+	var leader string
+	if err := c.getResponse(ctx, "getSlotLeader", formatParams(commitment, nil), &leader); err != nil {
+		return "", err
+	}
+
+	return leader, nil
+}
@@ -0,0 +1,39 @@
+package rpc
+
+import (
+	"encoding/json"
+)
+
+type (
+	SlotNotification struct {
+		Parent int64 `json:"parent"`
+		Root   int64 `json:"root"`
+		Slot   int64 `json:"slot"`
+	}
+
+	SlotSubscription struct {
+		Subscription
+		C <-chan SlotNotification
+	}
+)
+
+// https://docs.solana.com/developing/clients/jsonrpc-api#slotsubscribe
+func (c *PubSubClient) SlotSubscribe() *SlotSubscription {
+	ch := make(chan SlotNotification, subscriptionBuffer)
+	sub := &subscription{method: "slotSubscribe", params: []interface{}{}}
+	sub.notify = func(result json.RawMessage) {
+		var n SlotNotification
+		if err := json.Unmarshal(result, &n); err != nil {
+			sub.logDropped(err)
+			return
+		}
+		select {
+		case ch <- n:
+		default:
+			sub.logDropped(nil)
+		}
+	}
+	sub.close = func() { close(ch) }
+
+	return &SlotSubscription{Subscription: c.subscribe(sub), C: ch}
+}
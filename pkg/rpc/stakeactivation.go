@@ -0,0 +1,31 @@
+package rpc
+
+import (
+	"context"
+)
+
+type (
+	StakeActivationInfo struct {
+		// the stake account's activation state, one of: active, inactive, activating, deactivating
+		State string `json:"state"`
+		// stake active during the epoch
+		Active int64 `json:"active"`
+		// stake inactive during the epoch
+		Inactive int64 `json:"inactive"`
+	}
+)
+
+// https://docs.solana.com/developing/clients/jsonrpc-api#getstakeactivation
+func (c *RPCClient) GetStakeActivation(ctx context.Context, commitment Commitment, pubkey string) (*StakeActivationInfo, error) {
+	var info StakeActivationInfo
+	if err := c.getResponse(ctx, "getStakeActivation", formatParams(commitment, nil, pubkey), &info); err != nil {
+		return nil, err
+	}
+
+	return &info, nil
+}
+
+// NewGetStakeActivationCall prepares a getStakeActivation call for RPCClient.Batch, decoding the activation into info.
+func NewGetStakeActivationCall(commitment Commitment, pubkey string, info *StakeActivationInfo) *BatchCall {
+	return NewBatchCall("getStakeActivation", formatParams(commitment, nil, pubkey), info)
+}
@@ -0,0 +1,35 @@
+package rpc
+
+import (
+	"context"
+)
+
+type (
+	TokenAmount struct {
+		// the raw amount without decimals, a string representation of u64
+		Amount string `json:"amount"`
+		// number of base 10 digits to the right of the decimal place
+		Decimals int64 `json:"decimals"`
+		// the amount, using mint-prescribed decimals (deprecated by the node in favour of UiAmountString)
+		UiAmount float64 `json:"uiAmount"`
+		// the amount as a string, using mint-prescribed decimals
+		UiAmountString string `json:"uiAmountString"`
+	}
+)
+
+// https://docs.solana.com/developing/clients/jsonrpc-api#gettokenaccountbalance
+func (c *RPCClient) GetTokenAccountBalance(ctx context.Context, commitment Commitment, pubkey string) (*TokenAmount, Context, error) {
+	var balance TokenAmount
+	rctx, err := c.getContextResponse(ctx, "getTokenAccountBalance", formatParams(commitment, nil, pubkey), &balance)
+	if err != nil {
+		return nil, rctx, err
+	}
+
+	return &balance, rctx, nil
+}
+
+// NewGetTokenAccountBalanceCall prepares a getTokenAccountBalance call for RPCClient.Batch, decoding the balance into
+// balance.
+func NewGetTokenAccountBalanceCall(commitment Commitment, pubkey string, balance *TokenAmount) *BatchCall {
+	return newContextBatchCall("getTokenAccountBalance", formatParams(commitment, nil, pubkey), balance)
+}
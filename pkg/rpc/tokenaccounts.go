@@ -0,0 +1,45 @@
+package rpc
+
+import (
+	"context"
+)
+
+type (
+	// TokenAccountsFilter restricts token account queries to a single mint or token program. Exactly one of the
+	// fields must be set.
+	TokenAccountsFilter struct {
+		Mint      string
+		ProgramID string
+	}
+
+	TokenAccount struct {
+		// base-58 encoded Pubkey of the token account
+		Pubkey  string      `json:"pubkey"`
+		Account AccountInfo `json:"account"`
+	}
+)
+
+func (f TokenAccountsFilter) param() map[string]string {
+	if f.Mint != "" {
+		return map[string]string{"mint": f.Mint}
+	}
+	return map[string]string{"programId": f.ProgramID}
+}
+
+// https://docs.solana.com/developing/clients/jsonrpc-api#gettokenaccountsbyowner
+// Account data is requested in the jsonParsed encoding.
+func (c *RPCClient) GetTokenAccountsByOwner(ctx context.Context, commitment Commitment, owner string, filter TokenAccountsFilter) ([]TokenAccount, Context, error) {
+	var accounts []TokenAccount
+	rctx, err := c.getContextResponse(ctx, "getTokenAccountsByOwner",
+		formatParams(commitment, map[string]interface{}{"encoding": string(EncodingJSONParsed)}, owner, filter.param()), &accounts)
+	return accounts, rctx, err
+}
+
+// https://docs.solana.com/developing/clients/jsonrpc-api#gettokenaccountsbydelegate
+// Account data is requested in the jsonParsed encoding.
+func (c *RPCClient) GetTokenAccountsByDelegate(ctx context.Context, commitment Commitment, delegate string, filter TokenAccountsFilter) ([]TokenAccount, Context, error) {
+	var accounts []TokenAccount
+	rctx, err := c.getContextResponse(ctx, "getTokenAccountsByDelegate",
+		formatParams(commitment, map[string]interface{}{"encoding": string(EncodingJSONParsed)}, delegate, filter.param()), &accounts)
+	return accounts, rctx, err
+}
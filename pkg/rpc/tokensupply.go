@@ -0,0 +1,16 @@
+package rpc
+
+import (
+	"context"
+)
+
+// https://docs.solana.com/developing/clients/jsonrpc-api#gettokensupply
+func (c *RPCClient) GetTokenSupply(ctx context.Context, commitment Commitment, mint string) (*TokenAmount, Context, error) {
+	var supply TokenAmount
+	rctx, err := c.getContextResponse(ctx, "getTokenSupply", formatParams(commitment, nil, mint), &supply)
+	if err != nil {
+		return nil, rctx, err
+	}
+
+	return &supply, rctx, nil
+}
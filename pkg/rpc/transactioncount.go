@@ -0,0 +1,16 @@
+package rpc
+
+import (
+	"context"
+)
+
+// https://docs.solana.com/developing/clients/jsonrpc-api#gettransactioncount
+// Returns the current transaction count from the ledger.
+func (c *RPCClient) GetTransactionCount(ctx context.Context, commitment Commitment) (int64, error) {
+	var count int64
+	if err := c.getResponse(ctx, "getTransactionCount", formatParams(commitment, nil), &count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
@@ -2,44 +2,44 @@ package rpc
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
-
-	"k8s.io/klog/v2"
+	"strconv"
+	"strings"
 )
 
 type (
-	GetVersionResponse struct {
-		Result struct {
-			// software version of solana-core
-			Version string `json:"solana-core"`
-		} `json:"result"`
-		Error rpcError `json:"error"`
+	Version struct {
+		// software version of solana-core
+		SolanaCore string `json:"solana-core"`
+		// unique identifier of the current software's feature set
+		FeatureSet int64 `json:"feature-set"`
 	}
 )
 
 // https://docs.solana.com/developing/clients/jsonrpc-api#getversion
-func (c *RPCClient) GetVersion(ctx context.Context, rpcAddr string) (*string, error) {
-	body, err := c.rpcRequest(ctx, formatRPCRequest("getVersion", []interface{}{}), rpcAddr)
-
-	if body == nil {
-		return nil, fmt.Errorf("RPC call failed: Body empty")
-	}
-
-	if err != nil {
-		return nil, fmt.Errorf("RPC call failed: %w", err)
+func (c *RPCClient) GetVersion(ctx context.Context) (*Version, error) {
+	var version Version
+	if err := c.getResponse(ctx, "getVersion", []interface{}{}, &version); err != nil {
+		return nil, err
 	}
 
-	klog.V(2).Infof("version response: %v", string(body))
+	return &version, nil
+}
 
-	var resp GetVersionResponse
-	if err = json.Unmarshal(body, &resp); err != nil {
-		return nil, fmt.Errorf("failed to decode response body: %w", err)
+// AtLeast reports whether solana-core is at version major.minor or later. Versions that cannot be parsed are
+// considered older.
+func (v *Version) AtLeast(major, minor int) bool {
+	parts := strings.SplitN(v.SolanaCore, ".", 3)
+	if len(parts) < 2 {
+		return false
 	}
-
-	if resp.Error.Code != 0 {
-		return nil, fmt.Errorf("RPC error: %d %v", resp.Error.Code, resp.Error.Message)
+	vMajor, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return false
+	}
+	vMinor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return false
 	}
 
-	return &resp.Result.Version, nil
+	return vMajor > major || (vMajor == major && vMinor >= minor)
 }
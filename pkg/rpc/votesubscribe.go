@@ -0,0 +1,44 @@
+package rpc
+
+import (
+	"encoding/json"
+)
+
+type (
+	VoteNotification struct {
+		Hash  string  `json:"hash"`
+		Slots []int64 `json:"slots"`
+		// Unix timestamp of the vote, nil if the vote carries none.
+		Timestamp *int64 `json:"timestamp"`
+		// Only reported by newer node versions.
+		VotePubkey string `json:"votePubkey"`
+		Signature  string `json:"signature"`
+	}
+
+	VoteSubscription struct {
+		Subscription
+		C <-chan VoteNotification
+	}
+)
+
+// https://docs.solana.com/developing/clients/jsonrpc-api#votesubscribe---unstable-disabled-by-default
+// Nodes only accept this subscription if started with --rpc-pubsub-enable-vote-subscription.
+func (c *PubSubClient) VoteSubscribe() *VoteSubscription {
+	ch := make(chan VoteNotification, subscriptionBuffer)
+	sub := &subscription{method: "voteSubscribe", params: []interface{}{}}
+	sub.notify = func(result json.RawMessage) {
+		var n VoteNotification
+		if err := json.Unmarshal(result, &n); err != nil {
+			sub.logDropped(err)
+			return
+		}
+		select {
+		case ch <- n:
+		default:
+			sub.logDropped(nil)
+		}
+	}
+	sub.close = func() { close(ch) }
+
+	return &VoteSubscription{Subscription: c.subscribe(sub), C: ch}
+}